@@ -0,0 +1,306 @@
+package opts_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func TestParseAliases(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args       []string
+		wantOutput string
+		wantHelp   bool
+		wantColor  bool
+	}{
+		"canonical names": {
+			args:       []string{"--output", "x", "--help"},
+			wantOutput: "x",
+			wantHelp:   true,
+			wantColor:  true,
+		},
+		"aliases": {
+			args:       []string{"-o", "x", "-h"},
+			wantOutput: "x",
+			wantHelp:   true,
+			wantColor:  true,
+		},
+		"alias with equals": {
+			args:       []string{"--out=x"},
+			wantOutput: "x",
+			wantColor:  true,
+		},
+		"negated alias": {
+			args: []string{"--no-colour"},
+		},
+		"alias and name share one value": {
+			args:       []string{"-o", "x", "--output", "y"},
+			wantOutput: "y",
+			wantColor:  true,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var output string
+			var help, color bool
+
+			og := opts.NewGroup("test-alias")
+			og.StringZero(&output, "output", opts.Alias("o", "out"))
+			og.Bool(&help, "help", opts.Alias("h"))
+			og.NegatableBool(&color, "color", true, opts.Alias("colour"))
+
+			if err := og.Parse(tc.args); err != nil {
+				t.Fatalf("og.Parse(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if output != tc.wantOutput {
+				t.Errorf("og.Parse(%v) assigns %q to output; want %q", tc.args, output, tc.wantOutput)
+			}
+			if help != tc.wantHelp {
+				t.Errorf("og.Parse(%v) assigns %t to help; want %t", tc.args, help, tc.wantHelp)
+			}
+			if color != tc.wantColor {
+				t.Errorf("og.Parse(%v) assigns %t to color; want %t", tc.args, color, tc.wantColor)
+			}
+		})
+	}
+}
+
+func TestParseAliasErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args       []string
+		errWanted  error
+		errMessage string
+	}{
+		"missing value": {
+			args:       []string{"-o"},
+			errWanted:  opts.ErrMissingValue,
+			errMessage: "opts: --output: missing required value",
+		},
+		"boolean with value": {
+			args:       []string{"-h=yes"},
+			errWanted:  opts.ErrBooleanWithValue,
+			errMessage: "opts: --help=yes: boolean options do not accept values",
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var output string
+			var help, color bool
+
+			og := opts.NewGroup("test-alias")
+			og.StringZero(&output, "output", opts.Alias("o", "out"))
+			og.Bool(&help, "help", opts.Alias("h"))
+			og.NegatableBool(&color, "color", true, opts.Alias("colour"))
+
+			err := og.Parse(tc.args)
+
+			if !errors.Is(err, tc.errWanted) {
+				t.Fatalf("og.Parse(%v) returns err == %v; want %v", tc.args, err, tc.errWanted)
+			}
+
+			if got := err.Error(); got != tc.errMessage {
+				t.Errorf("err.Error() = %q; want %q", got, tc.errMessage)
+			}
+		})
+	}
+}
+
+func TestAliasConfigErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		config     string
+		errMessage string
+	}{
+		"missing value": {
+			config:     "out =\n",
+			errMessage: "opts: test.ini:1: --output: missing required value",
+		},
+		"bare key": {
+			config:     "o\n",
+			errMessage: "opts: test.ini:1: --output: missing required value",
+		},
+		"negated alias with value": {
+			config:     "no-colour = yes\n",
+			errMessage: "opts: test.ini:1: --no-color: boolean options do not accept values",
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var output string
+			var help, color bool
+
+			og := opts.NewGroup("test-alias")
+			og.StringZero(&output, "output", opts.Alias("o", "out"))
+			og.Bool(&help, "help", opts.Alias("h"))
+			og.NegatableBool(&color, "color", true, opts.Alias("colour"))
+
+			err := og.ReadConfig(strings.NewReader(tc.config), "test.ini")
+			if err == nil {
+				t.Fatalf("og.ReadConfig(%q) returns err == nil; want error", tc.config)
+			}
+
+			if got := err.Error(); got != tc.errMessage {
+				t.Errorf("err.Error() = %q; want %q", got, tc.errMessage)
+			}
+		})
+	}
+}
+
+func TestAliasProvenance(t *testing.T) {
+	t.Parallel()
+
+	var output string
+	var help, color bool
+
+	og := opts.NewGroup("test-alias")
+	og.StringZero(&output, "output", opts.Alias("o", "out"))
+	og.Bool(&help, "help", opts.Alias("h"))
+	og.NegatableBool(&color, "color", true, opts.Alias("colour"))
+
+	args := []string{"-o", "x"}
+	if err := og.Parse(args); err != nil {
+		t.Fatalf("og.Parse(%v) returns err == %v; want nil", args, err)
+	}
+
+	for _, name := range []string{"output", "o", "out"} {
+		if !og.IsSet(name) {
+			t.Errorf("og.IsSet(%q) = false; want true", name)
+		}
+	}
+
+	want := &opts.Option{
+		Name:    "output",
+		Aliases: []string{"o", "out"},
+		Type:    "string",
+		Value:   "x",
+		Source:  opts.SourceCommandLine,
+	}
+	if diff := cmp.Diff(want, og.Lookup("o")); diff != "" {
+		t.Errorf("og.Lookup(%q); (-want +got):\n%s", "o", diff)
+	}
+
+	var names []string
+	og.VisitAll(func(o *opts.Option) { names = append(names, o.Name) })
+	if diff := cmp.Diff([]string{"color", "help", "output"}, names); diff != "" {
+		t.Errorf("og.VisitAll; (-want +got):\n%s", diff)
+	}
+}
+
+func TestAliasUsage(t *testing.T) {
+	t.Parallel()
+
+	var output string
+	var help, color bool
+
+	og := opts.NewGroup("test-alias")
+	og.StringZero(&output, "output", opts.Alias("o", "out"))
+	og.Bool(&help, "help", opts.Alias("h"))
+	og.NegatableBool(&color, "color", true, opts.Alias("colour"))
+	og.SetClustering(true)
+
+	var b strings.Builder
+	og.Usage(&b)
+
+	want := "Usage: test-alias [options]\n\nOptions:\n" +
+		"  --[no-]color, --[no-]colour  (default true)\n" +
+		"  --help, -h\n" +
+		"  --output, -o, --out string\n"
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("og.Usage(); (-want +got):\n%s", diff)
+	}
+}
+
+func TestAliasAbbreviations(t *testing.T) {
+	t.Parallel()
+
+	var output string
+	var help, color bool
+
+	og := opts.NewGroup("test-alias")
+	og.StringZero(&output, "output", opts.Alias("o", "out"))
+	og.Bool(&help, "help", opts.Alias("h"))
+	og.NegatableBool(&color, "color", true, opts.Alias("colour"))
+	og.SetAbbreviations(true)
+
+	// "--ou" matches both "output" and its alias "out", which are one option.
+	args := []string{"--ou", "x"}
+	if err := og.Parse(args); err != nil {
+		t.Fatalf("og.Parse(%v) returns err == %v; want nil", args, err)
+	}
+
+	if output != "x" {
+		t.Errorf("og.Parse(%v) sets output to %q; want %q", args, output, "x")
+	}
+}
+
+func TestAliasPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		define func(*opts.Group)
+	}{
+		"invalid alias": {
+			define: func(og *opts.Group) {
+				var s string
+				og.StringZero(&s, "name", opts.Alias("-n"))
+			},
+		},
+		"alias repeats an option": {
+			define: func(og *opts.Group) {
+				var s string
+				og.StringZero(&s, "name", opts.Alias("help"))
+			},
+		},
+		"alias repeats itself": {
+			define: func(og *opts.Group) {
+				var s string
+				og.StringZero(&s, "name", opts.Alias("n", "n"))
+			},
+		},
+		"alias repeats a negation": {
+			define: func(og *opts.Group) {
+				var s string
+				og.StringZero(&s, "name", opts.Alias("no-color"))
+			},
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var output string
+			var help, color bool
+
+			og := opts.NewGroup("test-alias")
+			og.StringZero(&output, "output", opts.Alias("o", "out"))
+			og.Bool(&help, "help", opts.Alias("h"))
+			og.NegatableBool(&color, "color", true, opts.Alias("colour"))
+
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("expected panic on invalid alias")
+				}
+			}()
+			tc.define(og)
+		})
+	}
+}
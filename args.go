@@ -0,0 +1,250 @@
+package opts
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// Positional arguments are stored as opts, so they share values, converters,
+// and modifiers with options, but they live apart from the options in the
+// Group and are assigned in order from the arguments left after parsing.
+
+// defineArg defines a single positional argument for the caller named
+// funcName. The variable that p points to keeps its value if an optional
+// argument is absent.
+func defineArg[T any](g *Group, funcName string, p *T, name string, convert func(string) (T, error), typeName string, mods []Modifier) {
+	o := &opt{
+		value: &value[T]{
+			ptr:     p,
+			convert: convert,
+		},
+		name:       name,
+		typeName:   typeName,
+		positional: true,
+	}
+
+	g.addArg(funcName, o, mods)
+}
+
+// defineArgs defines a variadic positional argument for the caller named
+// funcName.
+func defineArgs[T any](g *Group, funcName string, p *[]T, name string, convert func(string) (T, error), typeName string, mods []Modifier) {
+	o := &opt{
+		value: &sliceValue[T]{
+			ptr:     p,
+			convert: convert,
+		},
+		name:       name,
+		typeName:   typeName,
+		positional: true,
+		repeatable: true,
+	}
+
+	g.addArg(funcName, o, mods)
+}
+
+// addArg validates o, applies mods to it, and appends it to the Group's
+// positional arguments.
+func (g *Group) addArg(funcName string, o *opt, mods []Modifier) {
+	if err := validateName(funcName, o.name); err != nil {
+		panic(err)
+	}
+
+	for _, mod := range mods {
+		if err := mod(o); err != nil {
+			panic(fmt.Errorf("opts: %s: <%s>: %w", funcName, o.name, err))
+		}
+	}
+
+	if err := g.checkArg(o); err != nil {
+		panic(fmt.Errorf("opts: %s: <%s>: %w", funcName, o.name, err))
+	}
+
+	g.positionals = append(g.positionals, o)
+}
+
+// checkArg reports whether o may follow the positional arguments already
+// defined.
+func (g *Group) checkArg(o *opt) error {
+	if len(o.aliases) > 0 || o.envVar != "" || o.required || o.hasImplicit {
+		return errors.New("modifier does not apply to positional arguments")
+	}
+
+	for _, p := range g.positionals {
+		if p.name == o.name {
+			return errors.New("argument already set")
+		}
+	}
+
+	if len(g.positionals) == 0 {
+		return nil
+	}
+
+	last := g.positionals[len(g.positionals)-1]
+	switch {
+	case last.repeatable:
+		return fmt.Errorf("argument cannot follow variadic <%s>", last.name)
+	case last.optional && !o.optional:
+		return fmt.Errorf("required argument cannot follow optional <%s>", last.name)
+	default:
+		return nil
+	}
+}
+
+// assignArgs assigns args, in order, to the Group's positional arguments and
+// returns any arguments left over.
+func (g *Group) assignArgs(args []string) ([]string, error) {
+	for _, o := range g.positionals {
+		switch {
+		case len(args) == 0 && o.optional:
+			return args, nil
+		case len(args) == 0:
+			return nil, fmt.Errorf("opts: <%s>: %w", o.name, ErrMissingArgument)
+		case o.repeatable:
+			for _, arg := range args {
+				if err := o.set(arg, SourceCommandLine); err != nil {
+					return nil, invalidArg(o.name, arg, err)
+				}
+			}
+			return args[len(args):], nil
+		}
+
+		if err := o.set(args[0], SourceCommandLine); err != nil {
+			return nil, invalidArg(o.name, args[0], err)
+		}
+		args = args[1:]
+	}
+
+	return args, nil
+}
+
+// argSynopsis describes the Group's positional arguments for the first line
+// of help output, e.g., " <src> [<dst>]" or " <file>...".
+func (g *Group) argSynopsis() string {
+	var b strings.Builder
+	for _, o := range g.positionals {
+		label := o.argLabel()
+		if o.optional {
+			label = "[" + label + "]"
+		}
+		b.WriteString(" " + label)
+	}
+
+	return b.String()
+}
+
+// argsUsage writes a section of help output that lists the Group's
+// positional arguments.
+func (g *Group) argsUsage(w io.Writer) {
+	if len(g.positionals) == 0 {
+		return
+	}
+
+	width := 0
+	for _, o := range g.positionals {
+		width = max(width, len(o.argLabel()))
+	}
+
+	fmt.Fprintf(w, "\nArguments:\n")
+	for _, o := range g.positionals {
+		if o.help == "" {
+			fmt.Fprintf(w, "  %s\n", o.argLabel())
+			continue
+		}
+		fmt.Fprintf(w, "  %-*s  %s\n", width, o.argLabel(), o.help)
+	}
+}
+
+// argLabel returns the name of a positional argument as help output shows it.
+func (o *opt) argLabel() string {
+	if o.repeatable {
+		return "<" + o.name + ">..."
+	}
+
+	return "<" + o.name + ">"
+}
+
+// StringArg defines a positional string argument with the specified name.
+// The argument s points to a string variable that will store its value.
+//
+// Positional arguments are assigned in the order they are defined from the
+// arguments that remain after options are parsed. [*Group.Parse] and
+// [*Group.ParseKnown] return [ErrMissingArgument] if a required argument is
+// absent and an [*InvalidValueError] that names the argument if a value
+// cannot be converted. The [Optional] modifier makes an argument optional,
+// and the variable for an absent optional argument keeps its current value,
+// so set it to the default before calling Parse. Only the last argument may
+// be variadic (see [*Group.StringArgs]), and no required argument may follow
+// an optional one.
+//
+// StringArg will panic if name is not valid, repeats an existing argument,
+// or breaks the rules for argument order.
+func (g *Group) StringArg(s *string, name string, mods ...Modifier) {
+	defineArg(g, "StringArg", s, name, toString, "string", mods)
+}
+
+// IntArg is like [*Group.StringArg] but for an int argument.
+func (g *Group) IntArg(i *int, name string, mods ...Modifier) {
+	defineArg(g, "IntArg", i, name, toInt, "int", mods)
+}
+
+// UintArg is like [*Group.StringArg] but for a uint argument.
+func (g *Group) UintArg(u *uint, name string, mods ...Modifier) {
+	defineArg(g, "UintArg", u, name, toUint, "uint", mods)
+}
+
+// Float64Arg is like [*Group.StringArg] but for a float64 argument.
+func (g *Group) Float64Arg(f *float64, name string, mods ...Modifier) {
+	defineArg(g, "Float64Arg", f, name, toFloat64, "float64", mods)
+}
+
+// DurationArg is like [*Group.StringArg] but for a [time.Duration] argument.
+func (g *Group) DurationArg(d *time.Duration, name string, mods ...Modifier) {
+	defineArg(g, "DurationArg", d, name, time.ParseDuration, "duration", mods)
+}
+
+// DateArg is like [*Group.StringArg] but for a [civil.Date] argument.
+func (g *Group) DateArg(d *civil.Date, name string, mods ...Modifier) {
+	defineArg(g, "DateArg", d, name, civil.ParseDate, "date", mods)
+}
+
+// StringArgs defines a variadic positional string argument with the
+// specified name. It takes every argument that remains after the arguments
+// defined before it, and s points to a []string variable that will store
+// them in order. A variadic argument requires at least one value unless it
+// is [Optional]. See [*Group.StringArg] for the rules that all positional
+// arguments follow.
+func (g *Group) StringArgs(s *[]string, name string, mods ...Modifier) {
+	defineArgs(g, "StringArgs", s, name, toString, "string", mods)
+}
+
+// IntArgs is like [*Group.StringArgs] but for int arguments.
+func (g *Group) IntArgs(i *[]int, name string, mods ...Modifier) {
+	defineArgs(g, "IntArgs", i, name, toInt, "int", mods)
+}
+
+// UintArgs is like [*Group.StringArgs] but for uint arguments.
+func (g *Group) UintArgs(u *[]uint, name string, mods ...Modifier) {
+	defineArgs(g, "UintArgs", u, name, toUint, "uint", mods)
+}
+
+// Float64Args is like [*Group.StringArgs] but for float64 arguments.
+func (g *Group) Float64Args(f *[]float64, name string, mods ...Modifier) {
+	defineArgs(g, "Float64Args", f, name, toFloat64, "float64", mods)
+}
+
+// DurationArgs is like [*Group.StringArgs] but for [time.Duration]
+// arguments.
+func (g *Group) DurationArgs(d *[]time.Duration, name string, mods ...Modifier) {
+	defineArgs(g, "DurationArgs", d, name, time.ParseDuration, "duration", mods)
+}
+
+// DateArgs is like [*Group.StringArgs] but for [civil.Date] arguments.
+func (g *Group) DateArgs(d *[]civil.Date, name string, mods ...Modifier) {
+	defineArgs(g, "DateArgs", d, name, civil.ParseDate, "date", mods)
+}
@@ -0,0 +1,298 @@
+package opts_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func TestParseArgs(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args        []string
+		wantVerbose bool
+		wantSrc     string
+		wantCount   int
+		wantSince   civil.Date
+		wantWaits   []time.Duration
+	}{
+		"required only": {
+			args:      []string{"file"},
+			wantSrc:   "file",
+			wantCount: 1,
+		},
+		"some optional": {
+			args:        []string{"--verbose", "file", "3"},
+			wantVerbose: true,
+			wantSrc:     "file",
+			wantCount:   3,
+		},
+		"all with variadic tail": {
+			args:      []string{"file", "3", "2025-01-02", "1s", "2m"},
+			wantSrc:   "file",
+			wantCount: 3,
+			wantSince: civil.Date{Year: 2025, Month: 1, Day: 2},
+			wantWaits: []time.Duration{time.Second, 2 * time.Minute},
+		},
+		"after double dash": {
+			args:      []string{"--", "-file"},
+			wantSrc:   "-file",
+			wantCount: 1,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				verbose bool
+				src     string
+				since   civil.Date
+				waits   []time.Duration
+			)
+			count := 1
+			og := opts.NewGroup("test-args")
+			og.Bool(&verbose, "verbose")
+			og.StringArg(&src, "src")
+			og.IntArg(&count, "count", opts.Optional())
+			og.DateArg(&since, "since", opts.Optional())
+			og.DurationArgs(&waits, "wait", opts.Optional())
+
+			if err := og.Parse(tc.args); err != nil {
+				t.Fatalf("og.Parse(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if verbose != tc.wantVerbose {
+				t.Errorf("og.Parse(%v) assigns %t to verbose; want %t", tc.args, verbose, tc.wantVerbose)
+			}
+			if src != tc.wantSrc {
+				t.Errorf("og.Parse(%v) assigns %q to src; want %q", tc.args, src, tc.wantSrc)
+			}
+			if count != tc.wantCount {
+				t.Errorf("og.Parse(%v) assigns %d to count; want %d", tc.args, count, tc.wantCount)
+			}
+			if since != tc.wantSince {
+				t.Errorf("og.Parse(%v) assigns %v to since; want %v", tc.args, since, tc.wantSince)
+			}
+			if diff := cmp.Diff(tc.wantWaits, waits); diff != "" {
+				t.Errorf("og.Parse(%v) waits; (-want +got):\n%s", tc.args, diff)
+			}
+		})
+	}
+}
+
+func TestParseArgsErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args       []string
+		errWanted  error
+		errMessage string
+	}{
+		"missing required": {
+			args:       []string{"--verbose"},
+			errWanted:  opts.ErrMissingArgument,
+			errMessage: "opts: <src>: missing required argument",
+		},
+		"invalid int": {
+			args:       []string{"file", "three"},
+			errWanted:  &opts.InvalidValueError{Arg: "count"},
+			errMessage: `opts: invalid value "three" for <count>: invalid syntax`,
+		},
+		"invalid variadic value": {
+			args:       []string{"file", "3", "2025-01-02", "1s", "soon"},
+			errWanted:  &opts.InvalidValueError{Arg: "wait"},
+			errMessage: `opts: invalid value "soon" for <wait>: time: invalid duration "soon"`,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				verbose bool
+				src     string
+				since   civil.Date
+				waits   []time.Duration
+			)
+			count := 1
+			og := opts.NewGroup("test-args")
+			og.Bool(&verbose, "verbose")
+			og.StringArg(&src, "src")
+			og.IntArg(&count, "count", opts.Optional())
+			og.DateArg(&since, "since", opts.Optional())
+			og.DurationArgs(&waits, "wait", opts.Optional())
+
+			err := og.Parse(tc.args)
+
+			var want *opts.InvalidValueError
+			if errors.As(tc.errWanted, &want) {
+				var ive *opts.InvalidValueError
+				if !errors.As(err, &ive) || ive.Arg != want.Arg || ive.Option != "" {
+					t.Fatalf("og.Parse(%v) returns err == %v; want InvalidValueError for <%s>", tc.args, err, want.Arg)
+				}
+			} else if !errors.Is(err, tc.errWanted) {
+				t.Fatalf("og.Parse(%v) returns err == %v; want %v", tc.args, err, tc.errWanted)
+			}
+
+			if got := err.Error(); got != tc.errMessage {
+				t.Errorf("err.Error() = %q; want %q", got, tc.errMessage)
+			}
+		})
+	}
+}
+
+func TestParseArgsLeftovers(t *testing.T) {
+	t.Parallel()
+
+	args := []string{"a", "b", "c"}
+
+	var first, second string
+	og := opts.NewGroup("test-args")
+	og.StringArg(&first, "first")
+	og.StringArg(&second, "second")
+
+	rest, err := og.ParseKnown(args)
+	if err != nil {
+		t.Fatalf("og.ParseKnown(%v) returns err == %v; want nil", args, err)
+	}
+
+	if diff := cmp.Diff([]string{"c"}, rest); diff != "" {
+		t.Errorf("og.ParseKnown(%v); (-want +got):\n%s", args, diff)
+	}
+
+	og = opts.NewGroup("test-args")
+	og.StringArg(&first, "first")
+	og.StringArg(&second, "second")
+
+	var uae *opts.UnexpectedArgumentsError
+	if err := og.Parse(args); !errors.As(err, &uae) {
+		t.Errorf("og.Parse(%v) returns err == %v; want UnexpectedArgumentsError", args, err)
+	}
+}
+
+func TestArgsUsage(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		define func(*opts.Group)
+		want   string
+	}{
+		"arguments only": {
+			define: func(og *opts.Group) {
+				var (
+					src string
+					dst string
+				)
+				og.StringArg(&src, "src", opts.Help("file to copy"))
+				og.StringArg(&dst, "dst", opts.Optional())
+			},
+			want: "Usage: test-args <src> [<dst>]\n\nArguments:\n" +
+				"  <src>  file to copy\n" +
+				"  <dst>\n",
+		},
+		"options and variadic arguments": {
+			define: func(og *opts.Group) {
+				var (
+					verbose bool
+					counts  []uint
+				)
+				og.Bool(&verbose, "verbose")
+				og.UintArgs(&counts, "count", opts.Help("numbers to add"))
+			},
+			want: "Usage: test-args [options] <count>...\n\nOptions:\n" +
+				"  --verbose\n" +
+				"\nArguments:\n" +
+				"  <count>...  numbers to add\n",
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			og := opts.NewGroup("test-args")
+			tc.define(og)
+
+			var b strings.Builder
+			og.Usage(&b)
+
+			if diff := cmp.Diff(tc.want, b.String()); diff != "" {
+				t.Errorf("og.Usage(); (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestArgsPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		define func(*opts.Group)
+	}{
+		"invalid name": {
+			define: func(og *opts.Group) {
+				var s string
+				og.StringArg(&s, "")
+			},
+		},
+		"repeated name": {
+			define: func(og *opts.Group) {
+				var a, b string
+				og.StringArg(&a, "file")
+				og.StringArg(&b, "file")
+			},
+		},
+		"argument after variadic": {
+			define: func(og *opts.Group) {
+				var (
+					files []string
+					n     int
+				)
+				og.StringArgs(&files, "file")
+				og.IntArg(&n, "n")
+			},
+		},
+		"required after optional": {
+			define: func(og *opts.Group) {
+				var a, b float64
+				og.Float64Arg(&a, "a", opts.Optional())
+				og.Float64Arg(&b, "b")
+			},
+		},
+		"option modifier": {
+			define: func(og *opts.Group) {
+				var s string
+				og.StringArg(&s, "file", opts.Alias("f"))
+			},
+		},
+		"optional option": {
+			define: func(og *opts.Group) {
+				var s string
+				og.StringZero(&s, "file", opts.Optional())
+			},
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			og := opts.NewGroup("test-args")
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("expected panic on invalid argument definition")
+				}
+			}()
+			tc.define(og)
+		})
+	}
+}
@@ -0,0 +1,321 @@
+package opts
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect" //nolint:depguard // binding struct fields requires reflection
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"cloud.google.com/go/civil"
+)
+
+// Bind defines an option for each exported field of the struct that ptr
+// points to, using the definition method that matches the field's type. It
+// saves writing one definition per field for programs that keep their
+// settings in a struct.
+//
+//	cfg := struct {
+//		Convention string        `default:"camel" help:"naming convention to enforce"`
+//		Strictness uint          `default:"3" env:"CASER_STRICTNESS"`
+//		Verbosity  int           `opts:"verbose,counter" alias:"v"`
+//		Timeout    time.Duration `opts:",required"`
+//		Color      bool          `opts:",negatable" default:"true"`
+//		Log        struct {
+//			File  string
+//			Level slog.Level `default:"info"`
+//		}
+//	}{}
+//
+//	og.Bind(&cfg)
+//
+// The opts tag gives the option's name, followed by any of these flags,
+// separated by commas: "required" applies [Required], "counter" makes an int
+// field a [*Group.Counter], and "negatable" makes a bool field
+// a [*Group.NegatableBool]. Without a name, the option is named after the
+// field in kebab case, so DryRun becomes "dry-run". A tag of "-" skips the
+// field. Other tags supply modifiers: help for [Help], env for [Env], alias
+// for a comma-separated [Alias] list, and sep for [Split].
+//
+// Each field's value when Bind is called becomes the option's default, so
+// defaults may be set in a struct literal. The default tag overrides that
+// value and is converted as if it were given on the command line. Among
+// boolean fields, only negatable ones may default to true.
+//
+// Supported field types are those of the definition methods (bool, int,
+// uint, float64, string, [time.Duration], [civil.Date], and slices and
+// string-keyed maps of the non-boolean types), plus any type whose pointer
+// implements [Value] or both [encoding.TextUnmarshaler] and
+// [encoding.TextMarshaler], as for [*Group.TextVar]. A field of any other
+// struct type is bound in turn, with its options named after the field plus
+// "-" and their own names, as in "log-file" above. Embedded structs add no
+// prefix.
+//
+// Bind will panic if ptr is not a non-nil pointer to a struct, if a field has
+// an unsupported type or an invalid tag, or under any condition that makes
+// the matching definition method panic.
+func (g *Group) Bind(ptr any) {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		panic(errors.New("opts: Bind: argument must be a non-nil pointer to a struct"))
+	}
+
+	g.bindStruct(v.Elem(), "")
+}
+
+func (g *Group) bindStruct(v reflect.Value, prefix string) {
+	t := v.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("opts")
+		nested := isNestedStruct(sf.Type)
+		if skipField(sf, tag, nested) {
+			continue
+		}
+
+		name, flags, err := parseBindTag(tag)
+		if err != nil {
+			panic(fmt.Errorf("opts: Bind: field %s: %w", sf.Name, err))
+		}
+		if name == "" {
+			name = kebabCase(sf.Name)
+		}
+
+		fv := v.Field(i)
+		if nested {
+			if sf.Anonymous && tag == "" {
+				g.bindStruct(fv, prefix)
+			} else {
+				g.bindStruct(fv, prefix+name+"-")
+			}
+			continue
+		}
+
+		g.bindField(fv.Addr().Interface(), prefix+name, sf.Tag, flags)
+	}
+}
+
+// skipField reports whether Bind leaves a field alone. Embedded structs count
+// even if their type is unexported, since their exported fields are promoted.
+func skipField(sf reflect.StructField, tag string, nested bool) bool {
+	switch {
+	case tag == "-":
+		return true
+	case sf.Anonymous && nested:
+		return false
+	default:
+		return !sf.IsExported()
+	}
+}
+
+// bindFlags holds the flags that follow the name in an opts tag.
+type bindFlags struct {
+	required  bool
+	counter   bool
+	negatable bool
+}
+
+// check reports whether the flags, and a default if there is one, suit the
+// field that p points to.
+func (f bindFlags) check(p any, hasDefault bool) error {
+	_, isInt := p.(*int)
+	b, isBool := p.(*bool)
+
+	switch {
+	case f.counter && !isInt:
+		return errors.New("counter requires an int field")
+	case f.negatable && !isBool:
+		return errors.New("negatable requires a bool field")
+	case isBool && !f.negatable && (hasDefault || *b):
+		return errors.New("default requires a negatable bool field")
+	default:
+		return nil
+	}
+}
+
+func parseBindTag(tag string) (string, bindFlags, error) {
+	var flags bindFlags
+
+	name, rest, _ := strings.Cut(tag, ",")
+	for flag := range strings.SplitSeq(rest, ",") {
+		switch flag {
+		case "":
+		case "required":
+			flags.required = true
+		case "counter":
+			flags.counter = true
+		case "negatable":
+			flags.negatable = true
+		default:
+			return "", flags, fmt.Errorf("unknown tag flag %q", flag)
+		}
+	}
+
+	return name, flags, nil
+}
+
+// isNestedStruct reports whether t is a struct to bind field by field rather
+// than as a single option.
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t == reflect.TypeFor[civil.Date]() {
+		return false
+	}
+
+	pt := reflect.PointerTo(t)
+
+	return !pt.Implements(reflect.TypeFor[Value]()) &&
+		!pt.Implements(reflect.TypeFor[encoding.TextUnmarshaler]())
+}
+
+func (g *Group) bindField(p any, name string, tag reflect.StructTag, flags bindFlags) {
+	if err := validateName("Bind", name); err != nil {
+		panic(err)
+	}
+
+	def, hasDefault := tag.Lookup("default")
+	if err := flags.check(p, hasDefault); err != nil {
+		panic(fmt.Errorf("opts: Bind: --%s: %w", name, err))
+	}
+
+	mods := bindModifiers(tag, flags)
+	if hasDefault {
+		mods = append(mods, withDefault(def))
+	}
+
+	// Built-in types come first, since some, such as civil.Date, also
+	// implement encoding.TextUnmarshaler.
+	if define, ok := bindFuncs[reflect.TypeOf(p).Elem()]; ok {
+		define(g, p, name, mods)
+		return
+	}
+
+	g.bindSpecial(p, name, flags, mods)
+}
+
+// bindSpecial defines an option for a field whose type is not in bindFuncs:
+// a bool, an int, or a custom type. Options for custom types are defined
+// around the field's value as it is, so it stays the default.
+func (g *Group) bindSpecial(p any, name string, flags bindFlags, mods []Modifier) {
+	switch p := p.(type) {
+	case *bool:
+		if flags.negatable {
+			g.NegatableBool(p, name, *p, mods...)
+			return
+		}
+		g.Bool(p, name, mods...)
+	case *int:
+		if flags.counter {
+			// Counter starts at zero, so restore the field's value first.
+			mods = append([]Modifier{withDefault(strconv.Itoa(*p))}, mods...)
+			g.Counter(p, name, mods...)
+			return
+		}
+		g.Int(p, name, *p, mods...)
+	case Value:
+		g.Var(p, name, mods...)
+	case textVar:
+		g.addOpt("Bind", &opt{value: &textValue{ptr: p}, name: name, typeName: "value"}, mods)
+	case encoding.TextUnmarshaler:
+		panic(fmt.Errorf("opts: Bind: --%s: %T implements encoding.TextUnmarshaler but not encoding.TextMarshaler", name, p))
+	default:
+		panic(fmt.Errorf("opts: Bind: --%s: unsupported type %s", name, reflect.TypeOf(p).Elem()))
+	}
+}
+
+// bindModifiers returns the modifiers that a field's tags call for, apart from
+// its default.
+func bindModifiers(tag reflect.StructTag, flags bindFlags) []Modifier {
+	var mods []Modifier
+
+	if help, ok := tag.Lookup("help"); ok {
+		mods = append(mods, Help(help))
+	}
+	if env, ok := tag.Lookup("env"); ok {
+		mods = append(mods, Env(env))
+	}
+	if alias, ok := tag.Lookup("alias"); ok {
+		mods = append(mods, Alias(strings.Split(alias, ",")...))
+	}
+	if sep, ok := tag.Lookup("sep"); ok {
+		mods = append(mods, Split(sep))
+	}
+	if flags.required {
+		mods = append(mods, Required())
+	}
+
+	return mods
+}
+
+// withDefault replaces an option's default with one from a string, converted
+// as if it came from the command line. It must follow any Split modifier.
+func withDefault(def string) Modifier {
+	return func(o *opt) error {
+		if r, ok := o.value.(interface{ reset() }); ok {
+			r.reset()
+		}
+		if err := o.set(def, SourceDefault); err != nil {
+			return fmt.Errorf("invalid default %q: %w", def, err)
+		}
+		return nil
+	}
+}
+
+type bindFunc func(g *Group, p any, name string, mods []Modifier)
+
+// bindWith adapts a definition method to a bindFunc. The field's current
+// value becomes the default.
+func bindWith[T any](define func(*Group, *T, string, T, ...Modifier)) bindFunc {
+	return func(g *Group, p any, name string, mods []Modifier) {
+		if ptr, ok := p.(*T); ok {
+			define(g, ptr, name, *ptr, mods...)
+		}
+	}
+}
+
+// bindFuncs maps each field type that Bind supports, apart from bool, int,
+// and custom types, to its definition method.
+var bindFuncs = map[reflect.Type]bindFunc{
+	reflect.TypeFor[string]():        bindWith((*Group).String),
+	reflect.TypeFor[uint]():          bindWith((*Group).Uint),
+	reflect.TypeFor[float64]():       bindWith((*Group).Float64),
+	reflect.TypeFor[time.Duration](): bindWith((*Group).Duration),
+	reflect.TypeFor[civil.Date]():    bindWith((*Group).Date),
+
+	reflect.TypeFor[[]string]():        bindWith((*Group).StringSlice),
+	reflect.TypeFor[[]int]():           bindWith((*Group).IntSlice),
+	reflect.TypeFor[[]uint]():          bindWith((*Group).UintSlice),
+	reflect.TypeFor[[]float64]():       bindWith((*Group).Float64Slice),
+	reflect.TypeFor[[]time.Duration](): bindWith((*Group).DurationSlice),
+	reflect.TypeFor[[]civil.Date]():    bindWith((*Group).DateSlice),
+
+	reflect.TypeFor[map[string]string]():        bindWith((*Group).StringMap),
+	reflect.TypeFor[map[string]int]():           bindWith((*Group).IntMap),
+	reflect.TypeFor[map[string]uint]():          bindWith((*Group).UintMap),
+	reflect.TypeFor[map[string]float64]():       bindWith((*Group).Float64Map),
+	reflect.TypeFor[map[string]time.Duration](): bindWith((*Group).DurationMap),
+	reflect.TypeFor[map[string]civil.Date]():    bindWith((*Group).DateMap),
+}
+
+// kebabCase converts a Go field name to an option name. A hyphen goes before
+// each uppercase letter that follows a lowercase letter or digit or that
+// begins a word after an acronym, so TLSCert becomes "tls-cert".
+func kebabCase(s string) string {
+	runes := []rune(s)
+
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('-')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	return b.String()
+}
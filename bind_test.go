@@ -0,0 +1,285 @@
+package opts_test
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+type bindLog struct {
+	File  string
+	Level slog.Level `default:"warn"`
+}
+
+type bindCommon struct {
+	Quiet bool
+}
+
+type bindConfig struct {
+	bindCommon
+
+	Convention string        `default:"camel" help:"naming convention to enforce"`
+	Strictness uint          `default:"3"`
+	Verbosity  int           `opts:"verbose,counter" alias:"v"`
+	Ratio      float64       `opts:"ratio"`
+	Timeout    time.Duration `opts:",required"`
+	Since      civil.Date
+	Color      bool              `opts:",negatable" default:"true"`
+	DryRun     bool              `help:"report problems without fixing them"`
+	TLSCert    string            `env:"OPTS_TEST_TLS_CERT"`
+	Include    []string          `sep:","`
+	Labels     map[string]string `opts:"label"`
+	Log        bindLog
+	Skipped    string `opts:"-"`
+	unexported string
+}
+
+func TestBind(t *testing.T) {
+	t.Parallel()
+
+	base := bindConfig{
+		Convention: "camel",
+		Strictness: 3,
+		Timeout:    time.Second,
+		Color:      true,
+		Log:        bindLog{Level: slog.LevelWarn},
+	}
+
+	testCases := map[string]struct {
+		args []string
+		want func(bindConfig) bindConfig
+	}{
+		"defaults": {
+			args: []string{"--timeout", "1s"},
+			want: func(c bindConfig) bindConfig { return c },
+		},
+		"all fields": {
+			args: []string{
+				"--timeout", "1s",
+				"--convention", "snake",
+				"--strictness", "5",
+				"-v", "--verbose",
+				"--ratio", "0.5",
+				"--since", "2025-01-02",
+				"--no-color",
+				"--dry-run",
+				"--tls-cert", "cert.pem",
+				"--include", "a,b",
+				"--label", "env=prod",
+				"--log-file", "out.log",
+				"--log-level", "debug",
+				"--quiet",
+			},
+			want: func(c bindConfig) bindConfig {
+				c.Convention = "snake"
+				c.Strictness = 5
+				c.Verbosity = 2
+				c.Ratio = 0.5
+				c.Since = civil.Date{Year: 2025, Month: 1, Day: 2}
+				c.Color = false
+				c.DryRun = true
+				c.TLSCert = "cert.pem"
+				c.Include = []string{"a", "b"}
+				c.Labels = map[string]string{"env": "prod"}
+				c.Log = bindLog{File: "out.log", Level: slog.LevelDebug}
+				c.Quiet = true
+				return c
+			},
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var got bindConfig
+			og := opts.NewGroup("test-bind")
+			og.Bind(&got)
+
+			if err := og.Parse(tc.args); err != nil {
+				t.Fatalf("og.Parse(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			want := tc.want(base)
+			if diff := cmp.Diff(want, got, cmp.AllowUnexported(bindConfig{})); diff != "" {
+				t.Errorf("og.Parse(%v); (-want +got):\n%s", tc.args, diff)
+			}
+		})
+	}
+}
+
+func TestBindNames(t *testing.T) {
+	t.Parallel()
+
+	var cfg bindConfig
+	og := opts.NewGroup("test-bind")
+	og.Bind(&cfg)
+
+	var names []string
+	og.VisitAll(func(o *opts.Option) { names = append(names, o.Name) })
+
+	want := []string{
+		"color", "convention", "dry-run", "include", "label", "log-file",
+		"log-level", "quiet", "ratio", "since", "strictness", "timeout",
+		"tls-cert", "verbose",
+	}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("og.Bind; (-want +got):\n%s", diff)
+	}
+
+	if o := og.Lookup("convention"); o.Help != "naming convention to enforce" || o.Default != "camel" {
+		t.Errorf("og.Lookup(%q) = %+v; want help and default from tags", "convention", o)
+	}
+
+	if o := og.Lookup("tls-cert"); o.EnvVar != "OPTS_TEST_TLS_CERT" {
+		t.Errorf("og.Lookup(%q).EnvVar = %q; want %q", "tls-cert", o.EnvVar, "OPTS_TEST_TLS_CERT")
+	}
+
+	if o := og.Lookup("timeout"); !o.Required {
+		t.Errorf("og.Lookup(%q).Required = false; want true", "timeout")
+	}
+}
+
+func TestBindPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		arg any
+	}{
+		"not a pointer": {
+			arg: bindConfig{},
+		},
+		"nil pointer": {
+			arg: (*bindConfig)(nil),
+		},
+		"pointer to non-struct": {
+			arg: new(int),
+		},
+		"unsupported type": {
+			arg: &struct{ Ch chan int }{},
+		},
+		"unknown tag flag": {
+			arg: &struct {
+				N int `opts:"n,sometimes"`
+			}{},
+		},
+		"counter on a string": {
+			arg: &struct {
+				S string `opts:",counter"`
+			}{},
+		},
+		"default for a plain bool": {
+			arg: &struct {
+				B bool `default:"true"`
+			}{},
+		},
+		"plain bool set to true": {
+			arg: &struct{ B bool }{B: true},
+		},
+		"invalid default": {
+			arg: &struct {
+				N uint `default:"-1"`
+			}{},
+		},
+		"invalid name": {
+			arg: &struct {
+				N int `opts:"bad name"`
+			}{},
+		},
+		"unmarshal-only type": {
+			arg: &struct{ W word }{},
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			og := opts.NewGroup("test-bind")
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("expected panic on invalid binding")
+				}
+			}()
+			og.Bind(tc.arg)
+		})
+	}
+}
+
+func TestBindKeepsFieldValues(t *testing.T) {
+	t.Parallel()
+
+	cfg := struct {
+		Timeout   string
+		Retries   int
+		Verbosity int  `opts:"verbose,counter"`
+		Color     bool `opts:",negatable"`
+		Include   []string
+		Level     slog.Level
+		Name      string   `default:"tagged"`
+		Tags      []string `default:"x"`
+	}{
+		Timeout:   "preset",
+		Retries:   3,
+		Verbosity: 2,
+		Color:     true,
+		Include:   []string{"a"},
+		Level:     slog.LevelWarn,
+		Name:      "preset",
+		Tags:      []string{"a", "b"},
+	}
+	og := opts.NewGroup("test-bind")
+	og.Bind(&cfg)
+
+	args := []string{"--verbose"}
+	if err := og.Parse(args); err != nil {
+		t.Fatalf("og.Parse(%v) returns err == %v; want nil", args, err)
+	}
+
+	got := map[string]string{}
+	og.VisitAll(func(o *opts.Option) { got[o.Name] = o.Default })
+
+	want := map[string]string{
+		"timeout": "preset",
+		"retries": "3",
+		"verbose": "2",
+		"color":   "true",
+		"include": "[a]",
+		"level":   "WARN",
+		"name":    "tagged",
+		"tags":    "[x]",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("og.Bind defaults; (-want +got):\n%s", diff)
+	}
+
+	if cfg.Verbosity != 3 {
+		t.Errorf("og.Parse(%v) sets verbosity to %d; want 3", args, cfg.Verbosity)
+	}
+}
+
+func TestBindUsage(t *testing.T) {
+	t.Parallel()
+
+	var cfg struct {
+		Name    string `default:"anon" help:"who to greet"`
+		Verbose int    `opts:",counter" alias:"v"`
+	}
+	og := opts.NewGroup("test-bind")
+	og.Bind(&cfg)
+
+	var b strings.Builder
+	og.Usage(&b)
+
+	want := "Usage: test-bind [options]\n\nOptions:\n" +
+		"  --name string   who to greet (default \"anon\")\n" +
+		"  --verbose, --v\n"
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("og.Usage(); (-want +got):\n%s", diff)
+	}
+}
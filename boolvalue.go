@@ -7,7 +7,7 @@ import (
 // Bool defines a bool option with the specified name and a default value of
 // false. The argument b points to a bool variable that will store the value.
 // Bool will panic if name is not valid or repeats an existing option.
-func (g *Group) Bool(b *bool, name string) {
+func (g *Group) Bool(b *bool, name string, mods ...Modifier) {
 	if err := validateName("Bool", name); err != nil {
 		panic(err)
 	}
@@ -22,10 +22,33 @@ func (g *Group) Bool(b *bool, name string) {
 		isBool: true,
 	}
 
-	if err := g.optAlreadySet(name); err != nil {
+	g.addOpt("Bool", opt, mods)
+}
+
+// NegatableBool defines a bool option with the specified name and default
+// value that users can switch either way. The argument b points to a bool
+// variable that will store the value. On the command line, "--name" sets the
+// option to true and "--no-name" sets it to false. This makes it possible to
+// turn off a switch that defaults to true or that was turned on by the
+// environment or a configuration file. NegatableBool will panic if name is
+// not valid or if either name or "no-" plus name repeats an existing option.
+func (g *Group) NegatableBool(b *bool, name string, defValue bool, mods ...Modifier) {
+	if err := validateName("NegatableBool", name); err != nil {
 		panic(err)
 	}
-	g.opts[name] = opt
+
+	*b = defValue
+	opt := &opt{
+		value: &value[bool]{
+			ptr:     b,
+			convert: toBool,
+		},
+		name:      name,
+		isBool:    true,
+		negatable: true,
+	}
+
+	g.addOpt("NegatableBool", opt, mods)
 }
 
 func toBool(s string) (bool, error) {
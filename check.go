@@ -0,0 +1,104 @@
+package opts
+
+import (
+	"fmt"
+)
+
+// A constraint relates a set of options that must be used together or that
+// must not be used together.
+type constraint struct {
+	opts      []*opt
+	exclusive bool
+}
+
+// MutuallyExclusive declares that at most one of the named options may be
+// given. If users give more than one, [*Group.Parse] and [*Group.ParseKnown]
+// return a [*ConflictingOptionsError]. Options set in the environment count
+// as given, as do options set in a configuration file that is read before
+// parsing. MutuallyExclusive will panic if fewer than two names are given, if
+// a name repeats, or if a name does not belong to an option that is already
+// defined.
+func (g *Group) MutuallyExclusive(names ...string) {
+	g.addConstraint("MutuallyExclusive", names, true)
+}
+
+// RequiredTogether declares that if any of the named options is given, all
+// of them must be. E.g., a certificate option may be useless without a key
+// option. If users give some but not all of the options, [*Group.Parse] and
+// [*Group.ParseKnown] return an [*IncompleteOptionsError]. Options count as
+// given under the same conditions as for [*Group.MutuallyExclusive].
+// RequiredTogether will panic under the same conditions as
+// [*Group.MutuallyExclusive].
+func (g *Group) RequiredTogether(names ...string) {
+	g.addConstraint("RequiredTogether", names, false)
+}
+
+func (g *Group) addConstraint(funcName string, names []string, exclusive bool) {
+	if len(names) < 2 {
+		panic(fmt.Errorf("opts: %s: need at least two options", funcName))
+	}
+
+	c := constraint{
+		opts:      make([]*opt, 0, len(names)),
+		exclusive: exclusive,
+	}
+	seen := make(map[*opt]bool, len(names))
+
+	for _, name := range names {
+		opt, ok := g.opts[name]
+		if !ok {
+			panic(fmt.Errorf("opts: %s: --%s: %w", funcName, name, ErrUnknownOption))
+		}
+		if seen[opt] {
+			panic(fmt.Errorf("opts: %s: --%s repeated", funcName, name))
+		}
+		seen[opt] = true
+		c.opts = append(c.opts, opt)
+	}
+
+	g.constraints = append(g.constraints, c)
+}
+
+// check verifies that the parsed options satisfy the Group's requirements
+// and constraints.
+func (g *Group) check() error {
+	var missing []string
+	for _, name := range g.sortedNames() {
+		opt := g.opts[name]
+		if opt.required && opt.source == SourceDefault {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return &MissingOptionsError{Options: missing}
+	}
+
+	for _, c := range g.constraints {
+		if err := c.check(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c constraint) check() error {
+	var given, notGiven []string
+	for _, opt := range c.opts {
+		if opt.source == SourceDefault {
+			notGiven = append(notGiven, opt.name)
+			continue
+		}
+		given = append(given, opt.name)
+	}
+
+	switch {
+	case c.exclusive && len(given) > 1:
+		return &ConflictingOptionsError{Options: given}
+	case !c.exclusive && len(given) > 0 && len(notGiven) > 0:
+		return &IncompleteOptionsError{Given: given, Missing: notGiven}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,214 @@
+package opts_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func TestConstraintsSatisfied(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args []string
+	}{
+		"nothing given":       {args: []string{}},
+		"one exclusive given": {args: []string{"--write"}},
+		"both together given": {args: []string{"--tls-cert", "c.pem", "--tls-key", "k.pem"}},
+		"exclusive and together": {
+			args: []string{"--dry-run", "--tls-key", "k.pem", "--tls-cert", "c.pem"},
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				write, dryRun, diff bool
+				cert, key           string
+			)
+			og := opts.NewGroup("test-constraints")
+			og.Bool(&write, "write")
+			og.Bool(&dryRun, "dry-run")
+			og.Bool(&diff, "diff")
+			og.StringZero(&cert, "tls-cert")
+			og.StringZero(&key, "tls-key")
+			og.MutuallyExclusive("write", "dry-run", "diff")
+			og.RequiredTogether("tls-cert", "tls-key")
+
+			if err := og.Parse(tc.args); err != nil {
+				t.Errorf("og.Parse(%v) returns err == %v; want nil", tc.args, err)
+			}
+		})
+	}
+}
+
+func TestConflictingOptions(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		config string
+		args   []string
+		want   []string
+	}{
+		"two given": {
+			args: []string{"--dry-run", "--write"},
+			want: []string{"write", "dry-run"},
+		},
+		"three given": {
+			args: []string{"--diff", "--dry-run", "--write"},
+			want: []string{"write", "dry-run", "diff"},
+		},
+		"one from config read before parsing": {
+			config: "dry-run\n",
+			args:   []string{"--write"},
+			want:   []string{"write", "dry-run"},
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				write, dryRun, diff bool
+				cert, key           string
+			)
+			og := opts.NewGroup("test-constraints")
+			og.Bool(&write, "write")
+			og.Bool(&dryRun, "dry-run")
+			og.Bool(&diff, "diff")
+			og.StringZero(&cert, "tls-cert")
+			og.StringZero(&key, "tls-key")
+			og.MutuallyExclusive("write", "dry-run", "diff")
+			og.RequiredTogether("tls-cert", "tls-key")
+
+			if err := og.ReadConfig(strings.NewReader(tc.config), "test.ini"); err != nil {
+				t.Fatalf("og.ReadConfig(%q) returns err == %v; want nil", tc.config, err)
+			}
+
+			err := og.Parse(tc.args)
+
+			var coe *opts.ConflictingOptionsError
+			if !errors.As(err, &coe) {
+				t.Fatalf("og.Parse(%v) returns %T; want ConflictingOptionsError", tc.args, err)
+			}
+
+			if diff := cmp.Diff(tc.want, coe.Options); diff != "" {
+				t.Errorf("og.Parse(%v); (-want +got):\n%s", tc.args, diff)
+			}
+		})
+	}
+}
+
+func TestIncompleteOptions(t *testing.T) {
+	t.Parallel()
+
+	args := []string{"--tls-cert", "c.pem"}
+	var (
+		write, dryRun, diff bool
+		cert, key           string
+	)
+	og := opts.NewGroup("test-constraints")
+	og.Bool(&write, "write")
+	og.Bool(&dryRun, "dry-run")
+	og.Bool(&diff, "diff")
+	og.StringZero(&cert, "tls-cert")
+	og.StringZero(&key, "tls-key")
+	og.MutuallyExclusive("write", "dry-run", "diff")
+	og.RequiredTogether("tls-cert", "tls-key")
+
+	_, err := og.ParseKnown(args)
+
+	var ioe *opts.IncompleteOptionsError
+	if !errors.As(err, &ioe) {
+		t.Fatalf("og.ParseKnown(%v) returns %T; want IncompleteOptionsError", args, err)
+	}
+
+	want := &opts.IncompleteOptionsError{Given: []string{"tls-cert"}, Missing: []string{"tls-key"}}
+	if diff := cmp.Diff(want, ioe); diff != "" {
+		t.Errorf("og.ParseKnown(%v); (-want +got):\n%s", args, diff)
+	}
+
+	wantMsg := "opts: --tls-cert must be used with --tls-key"
+	if err.Error() != wantMsg {
+		t.Errorf("err.Error() == %q; want %q", err.Error(), wantMsg)
+	}
+}
+
+func TestInvalidConstraints(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		declare func(*opts.Group)
+	}{
+		"one name": {
+			declare: func(og *opts.Group) { og.MutuallyExclusive("write") },
+		},
+		"unknown name": {
+			declare: func(og *opts.Group) { og.RequiredTogether("write", "force") },
+		},
+		"repeated name": {
+			declare: func(og *opts.Group) { og.MutuallyExclusive("write", "write") },
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				write, dryRun, diff bool
+				cert, key           string
+			)
+			og := opts.NewGroup("test-constraints")
+			og.Bool(&write, "write")
+			og.Bool(&dryRun, "dry-run")
+			og.Bool(&diff, "diff")
+			og.StringZero(&cert, "tls-cert")
+			og.StringZero(&key, "tls-key")
+			og.MutuallyExclusive("write", "dry-run", "diff")
+			og.RequiredTogether("tls-cert", "tls-key")
+
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("expected panic on invalid constraint")
+				}
+			}()
+			tc.declare(og)
+		})
+	}
+}
+
+func TestConstraintsRetry(t *testing.T) {
+	t.Parallel()
+
+	var write, dryRun bool
+	og := opts.NewGroup("test-constraints")
+	og.Bool(&write, "write")
+	og.Bool(&dryRun, "dry-run")
+	og.MutuallyExclusive("write", "dry-run")
+
+	args := []string{"--write", "--dry-run"}
+	var coe *opts.ConflictingOptionsError
+	if err := og.Parse(args); !errors.As(err, &coe) {
+		t.Fatalf("og.Parse(%v) returns err == %v; want ConflictingOptionsError", args, err)
+	}
+
+	args = []string{"--write"}
+	if err := og.Parse(args); err != nil {
+		t.Fatalf("og.Parse(%v) after failure returns err == %v; want nil", args, err)
+	}
+
+	if !write || dryRun {
+		t.Errorf("og.Parse(%v) after failure sets write, dry-run = %t, %t; want true, false", args, write, dryRun)
+	}
+
+	if og.IsSet("dry-run") {
+		t.Errorf("og.IsSet(%q) = true after retry; want false", "dry-run")
+	}
+}
@@ -0,0 +1,129 @@
+package opts
+
+import (
+	"fmt"
+	"io"
+	"slices"
+)
+
+// A Command pairs an option [Group] with a function to run and any number of
+// named subcommands. Commands form a tree: the root command handles global
+// options, and each subcommand handles its own options.
+type Command struct {
+	group    *Group
+	run      func(args []string) error
+	children map[string]*Command
+	name     string
+}
+
+// NewCommand returns a pointer to a Command with an empty Group of the same
+// name. The function run may be nil if the command only dispatches to
+// subcommands.
+func NewCommand(name string, run func(args []string) error) *Command {
+	return &Command{
+		group:    NewGroup(name),
+		run:      run,
+		children: make(map[string]*Command),
+		name:     name,
+	}
+}
+
+// Name returns the name of the command.
+func (c *Command) Name() string {
+	return c.name
+}
+
+// Group returns the option Group that belongs to the command. Define the
+// command's options on this Group.
+func (c *Command) Group() *Group {
+	return c.group
+}
+
+// Subcommand defines a child command with the specified name and run
+// function and returns a pointer to it. The child's Group is named after the
+// full command path (e.g., "tool build"). Subcommand will panic if name is not
+// valid or repeats an existing subcommand.
+func (c *Command) Subcommand(name string, run func(args []string) error) *Command {
+	if err := validateName("Subcommand", name); err != nil {
+		panic(err)
+	}
+
+	if _, exists := c.children[name]; exists {
+		panic(fmt.Errorf("opts: subcommand %s already set", name))
+	}
+
+	child := NewCommand(name, run)
+	child.group.name = c.group.name + " " + name
+	c.children[name] = child
+
+	return child
+}
+
+// Run parses args with the command's Group and then dispatches.
+//
+// If the command has no subcommands, Run calls the command's run function with
+// any arguments that remain after parsing. Otherwise, the first remaining
+// argument selects a subcommand, and Run calls that subcommand's Run with the
+// arguments that follow it. If no arguments remain, Run calls the command's own
+// run function if it has one and returns [ErrMissingCommand] if not. If the
+// first remaining argument does not name a subcommand, Run returns
+// [ErrUnknownCommand].
+//
+// Since the subcommand is found among the arguments left after parsing, the
+// Group of a command with subcommands should not use interspersed parsing.
+//
+// As with [*Group.ParseKnown], args should not include the program name.
+func (c *Command) Run(args []string) error {
+	rest, err := c.group.ParseKnown(args)
+	if err != nil {
+		return err
+	}
+
+	if len(c.children) == 0 {
+		if c.run == nil {
+			return nil
+		}
+		return c.run(rest)
+	}
+
+	if len(rest) == 0 {
+		if c.run == nil {
+			return fmt.Errorf("opts: %s: %w", c.group.name, ErrMissingCommand)
+		}
+		return c.run(rest)
+	}
+
+	child, ok := c.children[rest[0]]
+	if !ok {
+		return fmt.Errorf("opts: %s: %w", rest[0], ErrUnknownCommand)
+	}
+
+	return child.Run(rest[1:])
+}
+
+// Usage writes a help screen for the command to w. The screen lists the
+// command's options, as [*Group.Usage] does, followed by the names of any
+// subcommands.
+func (c *Command) Usage(w io.Writer) {
+	if len(c.children) == 0 {
+		trailer := " [args]"
+		if len(c.group.positionals) > 0 {
+			trailer = c.group.argSynopsis()
+		}
+		c.group.usage(w, trailer)
+		return
+	}
+
+	c.group.usage(w, " <command> [args]")
+
+	names := make([]string, 0, len(c.children))
+	for name := range c.children {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	fmt.Fprintf(w, "\nCommands:\n")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s\n", name)
+	}
+}
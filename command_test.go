@@ -0,0 +1,190 @@
+package opts_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func TestCommandRun(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args        []string
+		wantRan     string
+		wantArgs    []string
+		wantVerbose bool
+		wantForce   bool
+	}{
+		"subcommand without options": {
+			args:     []string{"clean"},
+			wantRan:  "clean",
+			wantArgs: []string{},
+		},
+		"global and subcommand options": {
+			args:        []string{"--verbose", "build", "--force", "a.go", "b.go"},
+			wantRan:     "build",
+			wantArgs:    []string{"a.go", "b.go"},
+			wantVerbose: true,
+			wantForce:   true,
+		},
+		"double dash before subcommand": {
+			args:     []string{"--", "build", "a.go"},
+			wantRan:  "build",
+			wantArgs: []string{"a.go"},
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				ran     string
+				runArgs []string
+				verbose bool
+				force   bool
+			)
+			root := opts.NewCommand("tool", nil)
+			root.Group().Bool(&verbose, "verbose")
+
+			build := root.Subcommand("build", func(args []string) error {
+				ran = "build"
+				runArgs = args
+				return nil
+			})
+			build.Group().Bool(&force, "force")
+
+			root.Subcommand("clean", func(args []string) error {
+				ran = "clean"
+				runArgs = args
+				return nil
+			})
+
+			if err := root.Run(tc.args); err != nil {
+				t.Fatalf("root.Run(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if ran != tc.wantRan {
+				t.Errorf("root.Run(%v) runs %q; want %q", tc.args, ran, tc.wantRan)
+			}
+			if diff := cmp.Diff(tc.wantArgs, runArgs); diff != "" {
+				t.Errorf("root.Run(%v) args; (-want +got):\n%s", tc.args, diff)
+			}
+			if verbose != tc.wantVerbose {
+				t.Errorf("root.Run(%v) assigns %t to verbose; want %t", tc.args, verbose, tc.wantVerbose)
+			}
+			if force != tc.wantForce {
+				t.Errorf("root.Run(%v) assigns %t to force; want %t", tc.args, force, tc.wantForce)
+			}
+		})
+	}
+}
+
+func TestCommandRunErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		errWanted error
+		args      []string
+	}{
+		"no subcommand": {
+			args:      []string{"--verbose"},
+			errWanted: opts.ErrMissingCommand,
+		},
+		"unknown subcommand": {
+			args:      []string{"bild"},
+			errWanted: opts.ErrUnknownCommand,
+		},
+		"subcommand option before subcommand": {
+			args:      []string{"--force", "build"},
+			errWanted: opts.ErrUnknownOption,
+		},
+		"unknown subcommand option": {
+			args:      []string{"clean", "--force"},
+			errWanted: opts.ErrUnknownOption,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var verbose, force bool
+			root := opts.NewCommand("tool", nil)
+			root.Group().Bool(&verbose, "verbose")
+
+			build := root.Subcommand("build", func([]string) error { return nil })
+			build.Group().Bool(&force, "force")
+
+			root.Subcommand("clean", func([]string) error { return nil })
+
+			err := root.Run(tc.args)
+			if !errors.Is(err, tc.errWanted) {
+				t.Errorf("root.Run(%v) returns err == %v; want %v", tc.args, err, tc.errWanted)
+			}
+		})
+	}
+}
+
+func TestCommandRunWithoutSubcommand(t *testing.T) {
+	t.Parallel()
+
+	var got []string
+	root := opts.NewCommand("tool", func(args []string) error {
+		got = args
+		return nil
+	})
+	root.Subcommand("build", nil)
+
+	if err := root.Run([]string{}); err != nil {
+		t.Fatalf("root.Run([]) returns err == %v; want nil", err)
+	}
+
+	if diff := cmp.Diff([]string{}, got); diff != "" {
+		t.Errorf("root.Run([]); (-want +got):\n%s", diff)
+	}
+}
+
+func TestDuplicateSubcommand(t *testing.T) {
+	t.Parallel()
+
+	root := opts.NewCommand("tool", nil)
+	root.Subcommand("build", nil)
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic on duplicate subcommand")
+		}
+	}()
+	root.Subcommand("build", nil)
+}
+
+func TestCommandUsage(t *testing.T) {
+	t.Parallel()
+
+	var verbose, force bool
+	root := opts.NewCommand("tool", nil)
+	root.Group().Bool(&verbose, "verbose")
+
+	build := root.Subcommand("build", func([]string) error { return nil })
+	build.Group().Bool(&force, "force")
+
+	root.Subcommand("clean", func([]string) error { return nil })
+
+	want := "Usage: tool [options] <command> [args]\n\n" +
+		"Options:\n" +
+		"  --verbose\n\n" +
+		"Commands:\n" +
+		"  build\n" +
+		"  clean\n"
+
+	var b strings.Builder
+	root.Usage(&b)
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("root.Usage(); (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,169 @@
+package opts
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LoadConfig reads option values from the named file. See [*Group.ReadConfig]
+// for the file format.
+func (g *Group) LoadConfig(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opts: %w", err)
+	}
+	defer f.Close()
+
+	return g.ReadConfig(f, path)
+}
+
+// ReadConfig reads option values from r, using file to identify the source
+// in errors. The format is a simple INI-style file.
+//
+//	# Comments begin with "#" or ";".
+//	convention = snake
+//	rcfile = "my file.ini"
+//	dry-run
+//
+//	[caser]
+//	strictness = 5
+//
+// Each entry is a key, which must name an option in the Group, an equal sign,
+// and a value. Values may be wrapped in double quotes, which are read as a Go
+// string literal, or single quotes, which are read literally. A boolean option
+// may appear without a value, which means true, and so may an option with an
+// [Implicit] value. A counter without a value adds one, as on the command line.
+// A negatable boolean may appear as "no-" plus its name. Entries before the
+// first section header apply to every Group; entries after a header apply only
+// to the Group with that name. Values are converted exactly as they are on the
+// command line.
+//
+// Values from a file never replace values from the environment or the command
+// line. Thus ReadConfig may be called before or after [*Group.Parse], which
+// allows a program to take the name of its configuration file from an option.
+// But Parse checks [Required] options and constraints such as
+// [*Group.MutuallyExclusive] when it runs, so values read after Parse do not
+// count toward them.
+// If ReadConfig returns an error, the Group may have been partially updated.
+func (g *Group) ReadConfig(r io.Reader, file string) error {
+	sc := bufio.NewScanner(r)
+	inScope := true
+
+	for lineNum := 1; sc.Scan(); lineNum++ {
+		line := strings.TrimSpace(sc.Text())
+
+		var err error
+		switch {
+		case line == "" || line[0] == '#' || line[0] == ';':
+			continue
+		case line[0] == '[':
+			inScope, err = g.configSection(line)
+		case inScope:
+			err = g.configEntry(line)
+		}
+
+		if err != nil {
+			return &ConfigError{File: file, Line: lineNum, Err: err}
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("opts: %s: %w", file, err)
+	}
+
+	return nil
+}
+
+// configSection reports whether entries under the section header in line
+// apply to the Group.
+func (g *Group) configSection(line string) (bool, error) {
+	if line[len(line)-1] != ']' {
+		return false, fmt.Errorf("%s: %w", line, ErrConfigSyntax)
+	}
+
+	return strings.TrimSpace(line[1:len(line)-1]) == g.name, nil
+}
+
+func (g *Group) configEntry(line string) error {
+	key, value, eqFound := strings.Cut(line, "=")
+	key = strings.TrimSpace(key)
+	value = strings.TrimSpace(value)
+
+	if !isValidName(key) {
+		return fmt.Errorf("%s: %w", line, ErrConfigSyntax)
+	}
+
+	opt, negated, ok := g.lookup(key)
+	if !ok {
+		return g.unknownOption(key)
+	}
+
+	if opt.source > SourceConfig {
+		return nil
+	}
+
+	if negated || !eqFound {
+		return setBareKey(opt, negated, eqFound)
+	}
+
+	if value == "" {
+		return fmt.Errorf("--%s: %w", opt.name, ErrMissingValue)
+	}
+
+	value, err := unquote(value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", line, ErrConfigSyntax)
+	}
+
+	if err := opt.set(value, SourceConfig); err != nil {
+		return invalidValue(opt.name, value, err)
+	}
+
+	return nil
+}
+
+// setBareKey handles an entry that is a key alone or a negated key. The key
+// stands for a value: false for a negated boolean, true for a boolean, or an
+// option's implicit value. A bare counter adds one, as on the command line.
+// Errors name the option by its canonical name, even if the key is an alias.
+func setBareKey(opt *opt, negated, eqFound bool) error {
+	var value string
+
+	switch {
+	case negated && eqFound:
+		return fmt.Errorf("--no-%s: %w", opt.name, ErrBooleanWithValue)
+	case negated:
+		value = "false"
+	case opt.isBool:
+		value = "true"
+	case opt.isCounter:
+		opt.increment(SourceConfig)
+		return nil
+	case opt.hasImplicit:
+		value = opt.implicit
+	default:
+		return fmt.Errorf("--%s: %w", opt.name, ErrMissingValue)
+	}
+
+	if err := opt.set(value, SourceConfig); err != nil {
+		return invalidValue(opt.name, value, err)
+	}
+
+	return nil
+}
+
+// unquote removes double or single quotes from around value, if present.
+func unquote(value string) (string, error) {
+	switch {
+	case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+		return strconv.Unquote(value)
+	case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+		return value[1 : len(value)-1], nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,253 @@
+package opts_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/telemachus/opts"
+)
+
+func TestReadConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		config         string
+		args           []string
+		wantRcfile     string
+		wantConvention string
+		wantStrictness uint
+		wantDryRun     bool
+		wantWrite      bool
+	}{
+		"empty file": {
+			config:         "",
+			args:           []string{},
+			wantRcfile:     "caser.ini",
+			wantConvention: "camel",
+			wantStrictness: 3,
+		},
+		"comments, blank lines, and entries": {
+			config:         "# comment\n; another\n\nconvention = snake\nstrictness=5\n  dry-run  \nwrite = false\n",
+			args:           []string{},
+			wantRcfile:     "caser.ini",
+			wantConvention: "snake",
+			wantStrictness: 5,
+			wantDryRun:     true,
+		},
+		"quoted values": {
+			config:         "rcfile = \"my file.ini\"\nconvention = 'kebab'\n",
+			args:           []string{},
+			wantRcfile:     "my file.ini",
+			wantConvention: "kebab",
+			wantStrictness: 3,
+		},
+		"sections for other groups are skipped": {
+			config:         "strictness = 1\n[other]\nconvention = snake\nbogus = 1\n[caser]\nwrite\n",
+			args:           []string{},
+			wantRcfile:     "caser.ini",
+			wantConvention: "camel",
+			wantStrictness: 1,
+			wantWrite:      true,
+		},
+		"command line wins": {
+			config:         "strictness = 1\nconvention = snake\n",
+			args:           []string{"--strictness", "4"},
+			wantRcfile:     "caser.ini",
+			wantConvention: "snake",
+			wantStrictness: 4,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := struct {
+				rcfile     string
+				convention string
+				strictness uint
+				dryRun     bool
+				write      bool
+			}{}
+
+			og := opts.NewGroup("caser")
+			og.String(&cfg.rcfile, "rcfile", "caser.ini")
+			og.String(&cfg.convention, "convention", "camel")
+			og.Uint(&cfg.strictness, "strictness", 3)
+			og.Bool(&cfg.dryRun, "dry-run")
+			og.Bool(&cfg.write, "write")
+
+			if err := og.ReadConfig(strings.NewReader(tc.config), "caser.ini"); err != nil {
+				t.Fatalf("og.ReadConfig(%q) returns err == %v; want nil", tc.config, err)
+			}
+
+			if err := og.Parse(tc.args); err != nil {
+				t.Fatalf("og.Parse(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if cfg.rcfile != tc.wantRcfile {
+				t.Errorf("og.ReadConfig(%q) assigns %q to rcfile; want %q", tc.config, cfg.rcfile, tc.wantRcfile)
+			}
+			if cfg.convention != tc.wantConvention {
+				t.Errorf("og.ReadConfig(%q) assigns %q to convention; want %q", tc.config, cfg.convention, tc.wantConvention)
+			}
+			if cfg.strictness != tc.wantStrictness {
+				t.Errorf("og.ReadConfig(%q) assigns %d to strictness; want %d", tc.config, cfg.strictness, tc.wantStrictness)
+			}
+			if cfg.dryRun != tc.wantDryRun {
+				t.Errorf("og.ReadConfig(%q) assigns %t to dryRun; want %t", tc.config, cfg.dryRun, tc.wantDryRun)
+			}
+			if cfg.write != tc.wantWrite {
+				t.Errorf("og.ReadConfig(%q) assigns %t to write; want %t", tc.config, cfg.write, tc.wantWrite)
+			}
+		})
+	}
+}
+
+func TestReadConfigAfterParse(t *testing.T) {
+	t.Parallel()
+
+	var rcfile, convention string
+	var strictness uint
+
+	og := opts.NewGroup("caser")
+	og.String(&rcfile, "rcfile", "caser.ini")
+	og.String(&convention, "convention", "camel")
+	og.Uint(&strictness, "strictness", 3)
+
+	args := []string{"--rcfile", "custom.ini", "--strictness", "4"}
+	if err := og.Parse(args); err != nil {
+		t.Fatalf("og.Parse(%v) returns err == %v; want nil", args, err)
+	}
+
+	config := "strictness = 1\nconvention = snake\n"
+	if err := og.ReadConfig(strings.NewReader(config), rcfile); err != nil {
+		t.Fatalf("og.ReadConfig(%q) returns err == %v; want nil", config, err)
+	}
+
+	if strictness != 4 {
+		t.Errorf("og.ReadConfig(%q) assigns %d to strictness; want 4", config, strictness)
+	}
+	if convention != "snake" {
+		t.Errorf("og.ReadConfig(%q) assigns %q to convention; want %q", config, convention, "snake")
+	}
+}
+
+func TestReadConfigErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		errWanted error
+		config    string
+		line      int
+	}{
+		"unknown option": {
+			config:    "# comment\nverbose = true\n",
+			line:      2,
+			errWanted: opts.ErrUnknownOption,
+		},
+		"missing value": {
+			config:    "convention =\n",
+			line:      1,
+			errWanted: opts.ErrMissingValue,
+		},
+		"bare non-boolean": {
+			config:    "\n\nstrictness\n",
+			line:      3,
+			errWanted: opts.ErrMissingValue,
+		},
+		"malformed section": {
+			config:    "[caser\n",
+			line:      1,
+			errWanted: opts.ErrConfigSyntax,
+		},
+		"malformed entry": {
+			config:    "= value\n",
+			line:      1,
+			errWanted: opts.ErrConfigSyntax,
+		},
+		"bad quoting": {
+			config:    "rcfile = \"a\"b\"\n",
+			line:      1,
+			errWanted: opts.ErrConfigSyntax,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var rcfile, convention string
+			var strictness uint
+
+			og := opts.NewGroup("caser")
+			og.String(&rcfile, "rcfile", "caser.ini")
+			og.String(&convention, "convention", "camel")
+			og.Uint(&strictness, "strictness", 3)
+
+			err := og.ReadConfig(strings.NewReader(tc.config), "caser.ini")
+			if !errors.Is(err, tc.errWanted) {
+				t.Fatalf("og.ReadConfig(%q) returns err == %v; want %v", tc.config, err, tc.errWanted)
+			}
+
+			var ce *opts.ConfigError
+			if !errors.As(err, &ce) {
+				t.Fatalf("og.ReadConfig(%q) returns %T; want ConfigError", tc.config, err)
+			}
+
+			if ce.File != "caser.ini" || ce.Line != tc.line {
+				t.Errorf("og.ReadConfig(%q) reports %s:%d; want caser.ini:%d", tc.config, ce.File, ce.Line, tc.line)
+			}
+		})
+	}
+}
+
+func TestReadConfigInvalidValue(t *testing.T) {
+	t.Parallel()
+
+	var strictness uint
+	og := opts.NewGroup("caser")
+	og.Uint(&strictness, "strictness", 3)
+
+	config := "strictness = high\n"
+	err := og.ReadConfig(strings.NewReader(config), "caser.ini")
+
+	var ive *opts.InvalidValueError
+	if !errors.As(err, &ive) {
+		t.Fatalf("og.ReadConfig(%q) returns %T; want InvalidValueError", config, err)
+	}
+
+	want := `opts: caser.ini:1: invalid value "high" for --strictness: invalid syntax`
+	if err.Error() != want {
+		t.Errorf("err.Error() == %q; want %q", err.Error(), want)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "caser.ini")
+	if err := os.WriteFile(path, []byte("convention = snake\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var convention string
+	og := opts.NewGroup("caser")
+	og.String(&convention, "convention", "camel")
+
+	if err := og.LoadConfig(path); err != nil {
+		t.Fatalf("og.LoadConfig(%q) returns err == %v; want nil", path, err)
+	}
+
+	if convention != "snake" {
+		t.Errorf("og.LoadConfig(%q) assigns %q to convention; want %q", path, convention, "snake")
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.ini")
+	if err := og.LoadConfig(missing); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("og.LoadConfig(%q) returns err == %v; want os.ErrNotExist", missing, err)
+	}
+}
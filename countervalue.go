@@ -0,0 +1,73 @@
+package opts
+
+import (
+	"errors"
+	"strconv"
+)
+
+// A counterValue counts how many times a switch appears.
+type counterValue struct {
+	ptr *int
+}
+
+// set assigns an explicit count, as in "--verbose=3". Counts cannot be
+// negative.
+func (v *counterValue) set(s string) error {
+	n, err := toInt(s)
+	if err != nil {
+		return err
+	}
+
+	if n < 0 {
+		return errors.New("count must not be negative")
+	}
+
+	*v.ptr = n
+
+	return nil
+}
+
+func (v *counterValue) increment() {
+	*v.ptr++
+}
+
+func (v *counterValue) save() func() {
+	saved := *v.ptr
+	return func() { *v.ptr = saved }
+}
+
+func (v *counterValue) String() string {
+	return strconv.Itoa(*v.ptr)
+}
+
+func (v *counterValue) isZero() bool {
+	return *v.ptr == 0
+}
+
+// Counter defines a counting option with the specified name and a default
+// value of 0. The argument c points to an int variable that will store the
+// count. Like a boolean option, a counter is a switch: each appearance on the
+// command line adds one to the count, so "-v -v -v" yields 3, as does "-vvv"
+// when clustering is on. Users may also give an explicit count with an equal
+// sign, as in "--verbose=3", but not a negative one. Values from the
+// environment are always explicit counts, as are values from a configuration
+// file unless the key appears alone, and later appearances on the command line
+// add to them. Counter will panic if name is not valid or repeats an existing
+// option.
+func (g *Group) Counter(c *int, name string, mods ...Modifier) {
+	if err := validateName("Counter", name); err != nil {
+		panic(err)
+	}
+
+	*c = 0
+	opt := &opt{
+		value: &counterValue{
+			ptr: c,
+		},
+		name:      name,
+		isBool:    false,
+		isCounter: true,
+	}
+
+	g.addOpt("Counter", opt, mods)
+}
@@ -10,7 +10,7 @@ import (
 // option. On the command line, users must use a string in RFC 3339 full-date
 // format (e.g., "2025-12-31" or "2024-02-29"). See [civil.ParseDate] for
 // details.
-func (g *Group) Date(d *civil.Date, name string, defValue civil.Date) {
+func (g *Group) Date(d *civil.Date, name string, defValue civil.Date, mods ...Modifier) {
 	if err := validateName("Date", name); err != nil {
 		panic(err)
 	}
@@ -21,18 +21,16 @@ func (g *Group) Date(d *civil.Date, name string, defValue civil.Date) {
 			ptr:     d,
 			convert: civil.ParseDate,
 		},
-		name:   name,
-		isBool: false,
+		name:     name,
+		typeName: "date",
+		isBool:   false,
 	}
 
-	if err := g.optAlreadySet(name); err != nil {
-		panic(err)
-	}
-	g.opts[name] = opt
+	g.addOpt("Date", opt, mods)
 }
 
 // DateZero is like Date but it defaults to a zero value. NB: the zero value
 // for civil.Date is 0000-00-00, which most programs should not use as is.
-func (g *Group) DateZero(d *civil.Date, name string) {
-	g.Date(d, name, civil.Date{})
+func (g *Group) DateZero(d *civil.Date, name string, mods ...Modifier) {
+	g.Date(d, name, civil.Date{}, mods...)
 }
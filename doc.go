@@ -11,7 +11,11 @@ Options (aka, flags) are added to a Group by calling type-specific definition
 methods. These methods come in two forms: one that requires an explicit
 default value and one that uses the type's zero value as the default. E.g.,
 [*Group.String] versus [*Group.StringZero]. Boolean options are an exception:
-they always default to false, so there is only one method, [*Group.Bool].
+[*Group.Bool] always defaults to false, and [*Group.NegatableBool] takes an
+explicit default.
+Counters, defined with [*Group.Counter], are similar: they always start at
+zero, and each appearance on the command line adds one, so "-v -v -v" sets
+a verbosity of three.
 
 Valid option names must not be empty, must not begin with "-", and must not
 contain whitespace, control characters, quotes, backslashes, or equal signs.
@@ -33,12 +37,37 @@ returns without error, then the variables associated with options are ready for
 use. If a parsing method returns an error, then those variables are not safe to
 use.
 
+An unknown option is reported as an [*UnknownOptionError], which matches
+[ErrUnknownOption] with [errors.Is]. Its Suggestions field lists registered
+names close to the one given, and its message offers them: "opts:
+--strictnes: unknown option; did you mean --strictness?".
+
+Options are optional by default. The [Required] modifier marks an option as
+mandatory, and both parsing methods return a [*MissingOptionsError] listing
+every required option that received no value from the command line, the
+environment, or a configuration file read before parsing.
+
+Groups can also declare constraints among options. [*Group.MutuallyExclusive]
+forbids using more than one option from a set, and [*Group.RequiredTogether]
+requires all options from a set if any is used. Violations are reported after
+parsing as a [*ConflictingOptionsError] or an [*IncompleteOptionsError]. (See
+Configuration Files below for how values from a file count.)
+
+	og.MutuallyExclusive("write", "dry-run")
+	og.RequiredTogether("tls-cert", "tls-key")
+
 Parse is strict, returning [ErrUnexpectedArgs] if any non-option arguments
 remain. ParseKnown is relaxed and does not return an error in this situation.
 Both methods return the slice of leftover arguments, but only Parse treats
 a non-empty slice as an error. The relaxed behavior of ParseKnown is necessary
 for programs that accept positional arguments after the options.
 
+By default, parsing stops at the first non-option argument. Programs that
+want GNU-style behavior, where options may follow positional arguments, can
+call [*Group.SetInterspersed]. In that mode, parsing continues to the end of
+the arguments or to "--", and the positional arguments are collected, in
+order, as the leftovers.
+
 As an example, imagine a tool that validates and optionally corrects the case
 conventions in one or more files. Since such a tool expects one or more
 filenames after options have been set, it makes sense to use ParseKnown.
@@ -49,7 +78,7 @@ filenames after options have been set, it makes sense to use ParseKnown.
 		rcfile     string
 		convention string
 		strictness uint
-		verbosity  uint
+		verbosity  int
 		dryRun     bool
 		write      bool
 	}{}
@@ -57,7 +86,7 @@ filenames after options have been set, it makes sense to use ParseKnown.
 	og.String(&cfg.rcfile, "rcfile", "caser.ini")
 	og.String(&cfg.convention, "convention", "camel")
 	og.Uint(&cfg.strictness, "strictness", 3)
-	og.UintZero(&cfg.verbosity, "verbosity")
+	og.Counter(&cfg.verbosity, "verbose")
 	og.Bool(&cfg.dryRun, "dry-run")
 	og.Bool(&cfg.write, "write")
 
@@ -70,6 +99,206 @@ filenames after options have been set, it makes sense to use ParseKnown.
 	// If there is no error, the values in cfg are ready to use and
 	// remaining contains the names of files to check.
 
+# Positional Arguments
+
+Instead of converting the leftover arguments by hand, programs can declare
+named, typed positional arguments with methods such as [*Group.StringArg],
+[*Group.IntArg], and [*Group.DateArg]. After the options are parsed, the
+arguments that remain are assigned to them in order. The [Optional] modifier
+lets users leave an argument out, and variadic methods such as
+[*Group.StringArgs] collect every remaining argument. A missing argument is
+reported as [ErrMissingArgument], and a bad value as an [*InvalidValueError]
+whose Arg field names the argument.
+
+	og.StringArg(&cfg.src, "src")
+	og.StringArgs(&cfg.files, "file", opts.Optional())
+
+	// caser a.go b.go sets src to "a.go" and files to []string{"b.go"}.
+
+# Binding Structs
+
+Programs that keep their settings in a struct can define every option at once
+with [*Group.Bind]. Each exported field becomes an option named after the
+field in kebab case, and struct tags supply the rest: the opts tag gives
+a name and flags such as "required", and the default, help, env, alias, and
+sep tags supply a default and modifiers. Nested structs add their field name
+as a prefix.
+
+	cfg := struct {
+		RCFile     string `opts:"rcfile" default:"caser.ini"`
+		Convention string `default:"camel"`
+		Strictness uint   `default:"3"`
+		Verbosity  int    `opts:"verbose,counter"`
+		DryRun     bool
+		Write      bool
+	}{}
+
+	og.Bind(&cfg)
+
+# Enumerated Options
+
+[*Group.Enum] defines a string option that accepts only a fixed set of
+choices. Any other value makes the parsing methods return an
+[*InvalidValueError] that lists the valid choices, and [*Group.Usage] shows
+the choices in place of a type. [EnumOf] does the same for values of any
+comparable type, given a map from labels to values.
+
+	og.Enum(&cfg.convention, "convention", "camel", []string{"camel", "snake", "kebab"})
+
+# Custom Types
+
+Any type that implements [Value] can be an option with [*Group.Var]. Since
+Value is a subset of [flag.Value], types written for the flag package work
+unchanged. [VarOf] defines an option of any type, given a function that
+converts a string to that type. Either way, conversion errors are reported as
+an [*InvalidValueError], and the [Placeholder] modifier names the value in
+help output.
+
+	opts.VarOf(og, &cfg.endpoint, "endpoint", defaultURL, url.Parse, opts.Placeholder("url"))
+
+Types that implement both [encoding.TextUnmarshaler] and
+[encoding.TextMarshaler], such as [netip.Addr] and [slog.Level], need no
+conversion function. [*Group.TextVar] parses them with UnmarshalText and shows
+their values in help output with MarshalText.
+
+	og.TextVar(&cfg.listen, "listen", netip.MustParseAddr("127.0.0.1"))
+
+# The flag Package
+
+Programs moving from the standard [flag] package can move one piece at
+a time. [*Group.ImportFlagSet] defines an option for every flag in
+a [flag.FlagSet], sharing each flag's value, and [*Group.Var] accepts any
+single [flag.Value]. In the other direction, [*Group.ExportFlagSet] defines
+a flag for every option in a Group, so code that expects a FlagSet can parse
+into variables that opts manages.
+
+	fs := flag.NewFlagSet("legacy", flag.ContinueOnError)
+	legacy.RegisterFlags(fs)
+	og.ImportFlagSet(fs)
+
+# Repeatable Options
+
+By default, an option given more than once keeps its last value. Slice
+options, such as [*Group.StringSlice] and [*Group.DurationSlice], instead
+collect every value in order. The first value given replaces the default
+rather than adding to it. The [Split] modifier lets users also pass several
+values in one argument.
+
+	og.StringSliceZero(&cfg.include, "include", opts.Split(","))
+
+	// --include a --include b,c yields []string{"a", "b", "c"}.
+
+Map options, such as [*Group.StringMap] and [*Group.DurationMap], collect
+"key=value" pairs into a map. By default a repeated key keeps its last value;
+the [DuplicateKeys] modifier can keep the first value or reject repeats. If
+the value for a key cannot be converted, the [*InvalidValueError] names the
+key.
+
+	og.StringMapZero(&cfg.labels, "label")
+
+	// --label env=prod --label team=infra yields
+	// map[string]string{"env": "prod", "team": "infra"}.
+
+# Response Files
+
+Argument lists that are too long for a shell can be stored in response files.
+After a call to [*Group.SetResponseFiles], the parsing methods replace each
+"@path" argument with the whitespace-separated contents of that file, honoring
+single quotes, double quotes, and backslash escapes. Response files may name
+other response files, up to a fixed depth. An argument that begins with "@@"
+stands for a literal argument that begins with "@". Errors are reported as
+a [*ResponseFileError], which names the offending file.
+
+# Help Output
+
+Every option definition method accepts optional modifiers after its required
+arguments. The [Help] modifier attaches a short description to an option, and
+[*Group.Usage] writes a help screen listing every option in the group, sorted
+by name, with a placeholder for each option's type and any non-zero default.
+
+	og.String(&cfg.rcfile, "rcfile", "caser.ini", opts.Help("read settings from this file"))
+	og.Uint(&cfg.strictness, "strictness", 3, opts.Help("how strictly to check names"))
+
+	// Later...
+	og.Usage(os.Stderr)
+
+Programs that want to build their own help screen, or otherwise inspect
+a Group, can call [*Group.Lookup] for one option or [*Group.VisitAll] and
+[*Group.Visit] for all options or only those that are set. Each returns
+a read-only [Option] that describes the option's name, type, help text,
+default, and current value.
+
+# Environment Variables
+
+The [Env] modifier binds an option to an environment variable. When the group
+is parsed, values from the environment are assigned first, using the same
+conversions as the command line, and then the command line is parsed. Values
+given on the command line therefore win. If a variable holds a value that
+cannot be converted, the parsing methods return an [*InvalidValueError] whose
+EnvVar field names the variable.
+
+	og.String(&cfg.rcfile, "rcfile", "caser.ini", opts.Env("CASER_RCFILE"))
+
+# Configuration Files
+
+[*Group.LoadConfig] and [*Group.ReadConfig] read option values from a simple
+INI-style file of "key = value" entries. Each key must name an option, and
+each value is converted exactly as it would be on the command line. Values
+from a file never replace values from the environment or the command line,
+whichever is read first. Load the file before parsing, though, since the
+parsing methods check [Required] options and constraints when they run, and
+values from a file count toward them only if they are already set.
+
+	if err := og.LoadConfig("caser.ini"); err != nil {
+		// Handle the error.
+	}
+	if err := og.Parse(os.Args[1:]); err != nil {
+		// Handle the error.
+	}
+
+A program may instead parse the command line first and then load the file
+named by one of its options, but then its requirements and constraints see
+only the command line and the environment.
+
+Errors from these methods are reported as a [*ConfigError], which gives the
+file name and line number of the offending entry.
+
+After parsing and loading, [*Group.Source] reports where each option's value
+came from: its default, a configuration file, the environment, or the command
+line. [*Group.IsSet] reports whether an option received any value other than
+its default, and [*Group.Sources] iterates over every option. Programs that
+layer their own settings on top of opts can use these to decide precedence.
+
+	if !og.IsSet("strictness") {
+		cfg.strictness = projectDefaults.strictness
+	}
+
+# Subcommands
+
+Programs shaped like "tool <command> [options]" can use a [Command] instead of
+juggling several groups by hand. [NewCommand] creates a root command with its
+own Group for global options, and [*Command.Subcommand] registers named child
+commands, each with its own Group and run function. [*Command.Run] parses the
+global options, selects a subcommand from the first remaining argument, and
+runs it with the arguments that follow.
+
+	root := opts.NewCommand("tool", nil)
+	root.Group().Bool(&cfg.verbose, "verbose")
+
+	build := root.Subcommand("build", func(args []string) error {
+		// Build the files named in args.
+		return nil
+	})
+	build.Group().Bool(&cfg.force, "force")
+
+	if err := root.Run(os.Args[1:]); err != nil {
+		// Handle the error.
+	}
+
+Run returns [ErrUnknownCommand] if the first remaining argument does not name
+a subcommand and [ErrMissingCommand] if no arguments remain and the root has
+no run function of its own.
+
 # Command Line Syntax
 
 The syntax for options is largely the same as for Go's flag library.
@@ -81,25 +310,54 @@ The syntax for options is largely the same as for Go's flag library.
 
 On the command line, options can begin with one or two dashes; they are
 equivalent during parsing. As such, there is no distinction between long and
-short options. This means there is no way to stack options. That is, `-abc` is
-always read as one option, named "abc", rather than `-a -b -c`. Boolean options
-do not accept arguments; they are switches. All boolean options are initially
-false. If a boolean option is present on the command line, the option's value
-is set to true.
+short options by default. This means there is no way to stack options. That
+is, `-abc` is read as one option, named "abc", rather than `-a -b -c`. Boolean
+options do not accept arguments; they are switches. Boolean options defined
+with [*Group.Bool] are initially false. If a boolean option is present on the
+command line, the option's value is set to true.
+
+A boolean defined with [*Group.NegatableBool] may default to true, and it also
+answers to "no-" plus its name, which sets it to false. E.g., "--no-color"
+turns off a "color" option, even one switched on by the environment or
+a configuration file. Programs that want to accept "--name=true" and
+"--name=false" for every boolean option can call [*Group.SetBoolValues].
+
+The [Implicit] modifier gives a non-boolean option a value to use when it
+appears without one. Such an option never consumes the next argument, so an
+explicit value must follow an equal sign.
+
+	og.Enum(&cfg.color, "color", "never", []string{"auto", "always", "never"}, opts.Implicit("auto"))
+
+	// --color is the same as --color=auto.
+
+After a call to [*Group.SetAbbreviations], users may shorten an option name
+to any prefix that matches only one option, so "--verb" stands for
+"--verbose". An exact name always wins, and a prefix that matches several
+options makes the parsing methods return an [*AmbiguousOptionError] that lists
+the candidates.
+
+Programs that prefer POSIX conventions can call [*Group.SetClustering]. In
+that mode, a single dash introduces one or more single-character options, and
+longer names require two dashes.
+
+	-vxf file  // same as -v -x -f file
+	-ofile     // same as -o file
+	-n5        // same as -n 5
+	--name x   // long options need two dashes
 
 Although the library does not distinguish long from short options when parsing,
 it can provide users a short and a long option for use on the command line or
-in scripts.
+in scripts. The [Alias] modifier gives an option more names. All of an
+option's names share one value and one source, help output lists them on one
+line, and errors use the name the option was defined with.
 
 	cfg := struct {
 		helpWanted    bool
 		versionWanted bool
 	}{}
 
-	og.Bool(&cfg.helpWanted, "help")
-	og.Bool(&cfg.helpWanted, "h")
-	og.Bool(&cfg.versionWanted, "version")
-	og.Bool(&cfg.versionWanted, "V")
+	og.Bool(&cfg.helpWanted, "help", opts.Alias("h"))
+	og.Bool(&cfg.versionWanted, "version", opts.Alias("V"))
 
 # Valid Command Line Strings
 
@@ -10,7 +10,7 @@ import (
 // existing option. On the command line, users must pass a string in Go's
 // time.Duration format (e.g., "500ms" or "4h15m18s"). See [time.ParseDuration]
 // for details.
-func (g *Group) Duration(d *time.Duration, name string, defValue time.Duration) {
+func (g *Group) Duration(d *time.Duration, name string, defValue time.Duration, mods ...Modifier) {
 	if err := validateName("Duration", name); err != nil {
 		panic(err)
 	}
@@ -21,17 +21,15 @@ func (g *Group) Duration(d *time.Duration, name string, defValue time.Duration)
 			ptr:     d,
 			convert: time.ParseDuration,
 		},
-		name:   name,
-		isBool: false,
+		name:     name,
+		typeName: "duration",
+		isBool:   false,
 	}
 
-	if err := g.optAlreadySet(name); err != nil {
-		panic(err)
-	}
-	g.opts[name] = opt
+	g.addOpt("Duration", opt, mods)
 }
 
 // DurationZero is like Duration but with a default value of 0.
-func (g *Group) DurationZero(d *time.Duration, name string) {
-	g.Duration(d, name, 0)
+func (g *Group) DurationZero(d *time.Duration, name string, mods ...Modifier) {
+	g.Duration(d, name, 0, mods...)
 }
@@ -0,0 +1,121 @@
+package opts
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// An enumValue accepts only the labels in its set of choices.
+type enumValue[T comparable] struct {
+	ptr     *T
+	labels  map[string]T
+	choices []string
+}
+
+func (v *enumValue[T]) set(s string) error {
+	val, ok := v.labels[s]
+	if !ok {
+		// Omit "opts: " since the caller will provide context.
+		return fmt.Errorf("value must be one of %s", quotedArgs(v.choices))
+	}
+
+	*v.ptr = val
+
+	return nil
+}
+
+func (v *enumValue[T]) save() func() {
+	saved := *v.ptr
+	return func() { *v.ptr = saved }
+}
+
+// String returns the label for the current value.
+func (v *enumValue[T]) String() string {
+	for _, label := range v.choices {
+		if v.labels[label] == *v.ptr {
+			return label
+		}
+	}
+
+	return fmt.Sprint(*v.ptr)
+}
+
+// defineEnum defines an enumerated option for the caller named funcName. The
+// order of choices is the order used in errors and help output.
+func defineEnum[T comparable](g *Group, funcName string, p *T, name string, defValue T, labels map[string]T, choices []string, mods []Modifier) {
+	if err := validateName(funcName, name); err != nil {
+		panic(err)
+	}
+
+	if err := validateChoices(labels, choices, defValue); err != nil {
+		panic(fmt.Errorf("opts: %s: --%s: %w", funcName, name, err))
+	}
+
+	*p = defValue
+	opt := &opt{
+		value: &enumValue[T]{
+			ptr:     p,
+			labels:  labels,
+			choices: choices,
+		},
+		name:     name,
+		typeName: "enum",
+		choices:  choices,
+		isBool:   false,
+	}
+
+	g.addOpt(funcName, opt, mods)
+}
+
+func validateChoices[T comparable](labels map[string]T, choices []string, defValue T) error {
+	if len(choices) == 0 {
+		return errors.New("no choices")
+	}
+
+	if len(labels) != len(choices) {
+		return errors.New("duplicate choices")
+	}
+
+	for _, val := range labels {
+		if val == defValue {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("default %v is not a valid choice", defValue)
+}
+
+// Enum defines a string option with the specified name and default value
+// that accepts only the listed choices. The argument s points to a string
+// variable that will store the value of the option. If a user gives any other
+// value, the parsing methods return an [*InvalidValueError] that lists the
+// valid choices. The choices also appear, in order, in help output. Enum will
+// panic if name is not valid, repeats an existing option, or if choices is
+// empty, repeats a choice, or does not include defValue.
+func (g *Group) Enum(s *string, name, defValue string, choices []string, mods ...Modifier) {
+	labels := make(map[string]string, len(choices))
+	for _, choice := range choices {
+		labels[choice] = choice
+	}
+
+	defineEnum(g, "Enum", s, name, defValue, labels, slices.Clone(choices), mods)
+}
+
+// EnumOf is like [*Group.Enum] but for a value of any comparable type. The
+// argument choices maps each label that users may type to the value it
+// stands for. Help output and errors list the labels in sorted order. E.g.,
+//
+//	opts.EnumOf(og, &cfg.level, "level", slog.LevelInfo, map[string]slog.Level{
+//		"debug": slog.LevelDebug,
+//		"info":  slog.LevelInfo,
+//		"warn":  slog.LevelWarn,
+//	})
+//
+// EnumOf is a function rather than a method because methods cannot have type
+// parameters.
+func EnumOf[T comparable](g *Group, p *T, name string, defValue T, choices map[string]T, mods ...Modifier) {
+	labels := maps.Clone(choices)
+	defineEnum(g, "EnumOf", p, name, defValue, labels, slices.Sorted(maps.Keys(labels)), mods)
+}
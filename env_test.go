@@ -0,0 +1,125 @@
+package opts_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+// These tests cannot run in parallel because they call t.Setenv.
+
+func TestParseEnv(t *testing.T) {
+	testCases := map[string]struct {
+		env      map[string]string
+		args     []string
+		wantName string
+		wantWait time.Duration
+		wantQ    bool
+	}{
+		"no variables set": {
+			env:      map[string]string{},
+			args:     []string{},
+			wantName: "default",
+			wantWait: time.Second,
+		},
+		"variables set": {
+			env: map[string]string{
+				"OPTS_TEST_NAME":  "env",
+				"OPTS_TEST_WAIT":  "5m",
+				"OPTS_TEST_QUIET": "true",
+			},
+			args:     []string{},
+			wantName: "env",
+			wantWait: 5 * time.Minute,
+			wantQ:    true,
+		},
+		"command line wins": {
+			env: map[string]string{
+				"OPTS_TEST_NAME": "env",
+				"OPTS_TEST_WAIT": "5m",
+			},
+			args:     []string{"--name", "cli"},
+			wantName: "cli",
+			wantWait: 5 * time.Minute,
+		},
+		"empty string value": {
+			env:      map[string]string{"OPTS_TEST_NAME": ""},
+			args:     []string{},
+			wantName: "",
+			wantWait: time.Second,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			var (
+				name  string
+				wait  time.Duration
+				quiet bool
+			)
+			og := opts.NewGroup("test-env")
+			og.String(&name, "name", "default", opts.Env("OPTS_TEST_NAME"))
+			og.Duration(&wait, "wait", time.Second, opts.Env("OPTS_TEST_WAIT"))
+			og.Bool(&quiet, "quiet", opts.Env("OPTS_TEST_QUIET"))
+
+			if err := og.Parse(tc.args); err != nil {
+				t.Fatalf("og.Parse(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if name != tc.wantName {
+				t.Errorf("og.Parse(%v) assigns %q to name; want %q", tc.args, name, tc.wantName)
+			}
+			if wait != tc.wantWait {
+				t.Errorf("og.Parse(%v) assigns %v to wait; want %v", tc.args, wait, tc.wantWait)
+			}
+			if quiet != tc.wantQ {
+				t.Errorf("og.Parse(%v) assigns %t to quiet; want %t", tc.args, quiet, tc.wantQ)
+			}
+		})
+	}
+}
+
+func TestParseEnvInvalidValue(t *testing.T) {
+	t.Setenv("OPTS_TEST_COUNT", "lots")
+
+	var count int
+	og := opts.NewGroup("test-env")
+	og.Int(&count, "count", 1, opts.Env("OPTS_TEST_COUNT"))
+
+	err := og.Parse([]string{"--count", "2"})
+	var ive *opts.InvalidValueError
+	if !errors.As(err, &ive) {
+		t.Fatalf("og.Parse() returns %T; want InvalidValueError", err)
+	}
+
+	want := opts.InvalidValueError{Option: "count", Value: "lots", EnvVar: "OPTS_TEST_COUNT"}
+	got := opts.InvalidValueError{Option: ive.Option, Value: ive.Value, EnvVar: ive.EnvVar}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("og.Parse() error; (-want +got):\n%s", diff)
+	}
+
+	if !strings.Contains(err.Error(), "$OPTS_TEST_COUNT") {
+		t.Errorf("err.Error() == %q; want it to name $OPTS_TEST_COUNT", err.Error())
+	}
+}
+
+func TestEnvInvalidName(t *testing.T) {
+	t.Parallel()
+
+	og := opts.NewGroup("test-env")
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic on invalid environment variable name")
+		}
+	}()
+	var s string
+	og.StringZero(&s, "name", opts.Env("A=B"))
+}
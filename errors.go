@@ -15,9 +15,32 @@ var ErrAlreadyParsed = errors.New("already parsed")
 // a boolean.
 var ErrBooleanWithValue = errors.New("boolean options do not accept values")
 
+// ErrMissingArgument signals that a required positional argument is absent.
+var ErrMissingArgument = errors.New("missing required argument")
+
 // ErrMissingValue signals that an option is missing a required value.
 var ErrMissingValue = errors.New("missing required value")
 
+// ErrConfigSyntax signals a line in a configuration file that is neither
+// a comment, a section header, nor a "key = value" entry.
+var ErrConfigSyntax = errors.New("syntax error")
+
+// ErrDuplicateKey signals a repeated key for a map option that rejects
+// duplicates. See [DuplicateKeys].
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// ErrMissingCommand signals that a [Command] with subcommands was run without
+// naming one of them.
+var ErrMissingCommand = errors.New("missing command")
+
+// ErrResponseFileDepth signals that response files refer to other response
+// files too deeply, which may mean that a file refers to itself.
+var ErrResponseFileDepth = errors.New("response files nested too deeply")
+
+// ErrUnknownCommand signals that a subcommand was not registered with the
+// [Command].
+var ErrUnknownCommand = errors.New("unknown command")
+
 // ErrUnknownOption signals that an option was not registered with the [Group].
 var ErrUnknownOption = errors.New("unknown option")
 
@@ -50,19 +73,205 @@ func quotedArgs(items []string) string {
 	return b.String()
 }
 
+// UnknownOptionError signals that an option was not registered with the
+// [Group]. Name is the option as given, and Suggestions lists the registered
+// names closest to it, nearest first, which may be empty. UnknownOptionError
+// matches [ErrUnknownOption] with [errors.Is].
+type UnknownOptionError struct {
+	Name        string
+	Suggestions []string
+
+	// short is set for an option from a cluster, such as "-vq", so that
+	// Error shows it with one dash, as users gave it.
+	short bool
+}
+
+func (e *UnknownOptionError) Error() string {
+	dash := "--"
+	if e.short {
+		dash = "-"
+	}
+
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("opts: %s%s: %v", dash, e.Name, ErrUnknownOption)
+	}
+
+	return fmt.Sprintf("opts: %s%s: %v; did you mean %s?", dash, e.Name, ErrUnknownOption, dashedAlternatives(e.Suggestions))
+}
+
+// Is reports whether target is [ErrUnknownOption].
+func (e *UnknownOptionError) Is(target error) bool {
+	return target == ErrUnknownOption
+}
+
+// dashedAlternatives joins option names with a leading "--" for each name and
+// "or" before the last.
+func dashedAlternatives(names []string) string {
+	last := len(names) - 1
+	if last == 0 {
+		return "--" + names[0]
+	}
+
+	return dashedNames(names[:last]) + " or --" + names[last]
+}
+
+// AmbiguousOptionError signals that an abbreviated option name matches more
+// than one option. Name is the abbreviation, and Candidates lists every name
+// that it matches. See [*Group.SetAbbreviations].
+type AmbiguousOptionError struct {
+	Name       string
+	Candidates []string
+}
+
+func (e *AmbiguousOptionError) Error() string {
+	return fmt.Sprintf("opts: --%s: ambiguous option; could be %s", e.Name, dashedNames(e.Candidates))
+}
+
+// MissingOptionsError signals that one or more options marked with
+// [Required] were not given a value. Options lists every missing option.
+type MissingOptionsError struct {
+	Options []string
+}
+
+func (e *MissingOptionsError) Error() string {
+	var s string
+	if len(e.Options) > 1 {
+		s = "s"
+	}
+
+	return fmt.Sprintf("opts: missing required option%s: %s", s, dashedNames(e.Options))
+}
+
+// ConflictingOptionsError signals that users gave more than one option from
+// a set declared with [*Group.MutuallyExclusive]. Options lists the options
+// that were given, in the order they were declared.
+type ConflictingOptionsError struct {
+	Options []string
+}
+
+func (e *ConflictingOptionsError) Error() string {
+	return fmt.Sprintf("opts: options cannot be used together: %s", dashedNames(e.Options))
+}
+
+// IncompleteOptionsError signals that users gave some but not all of the
+// options from a set declared with [*Group.RequiredTogether]. Given lists
+// the options that were given, and Missing lists the rest.
+type IncompleteOptionsError struct {
+	Given   []string
+	Missing []string
+}
+
+func (e *IncompleteOptionsError) Error() string {
+	return fmt.Sprintf("opts: %s must be used with %s", dashedNames(e.Given), dashedNames(e.Missing))
+}
+
+// dashedNames joins option names with a leading "--" for each name.
+func dashedNames(names []string) string {
+	return "--" + strings.Join(names, ", --")
+}
+
 // InvalidValueError signals that an option's value cannot be converted into
 // the option's type. Since InvalidValueError wraps the original conversion
 // error, users can access the undedited original as InvalidValueError.Err.
+// If the value came from an environment variable, EnvVar names the variable.
+// If the option is a map, Key names the key whose value failed. If the value
+// belongs to a positional argument rather than an option, Arg names the
+// argument and Option is empty.
 type InvalidValueError struct {
 	Err    error
 	Option string
+	Arg    string
 	Value  string
+	EnvVar string
+	Key    string
 }
 
 func (e *InvalidValueError) Error() string {
-	return fmt.Sprintf("opts: invalid value %q for --%s: %v", e.Value, e.Option, e.Err)
+	var b strings.Builder
+	if e.Arg != "" {
+		fmt.Fprintf(&b, "opts: invalid value %q for <%s>", e.Value, e.Arg)
+	} else {
+		fmt.Fprintf(&b, "opts: invalid value %q for --%s", e.Value, e.Option)
+	}
+
+	if e.Key != "" {
+		fmt.Fprintf(&b, " at key %q", e.Key)
+	}
+
+	if e.EnvVar != "" {
+		fmt.Fprintf(&b, " from $%s", e.EnvVar)
+	}
+
+	fmt.Fprintf(&b, ": %v", e.Err)
+
+	return b.String()
 }
 
 func (e *InvalidValueError) Unwrap() error {
 	return e.Err
 }
+
+// invalidValue returns an InvalidValueError for a failed conversion. If the
+// failure was for a key of a map option, the key is lifted into the error.
+func invalidValue(name, value string, err error) *InvalidValueError {
+	ive := &InvalidValueError{
+		Option: name,
+		Value:  value,
+		Err:    err,
+	}
+
+	var ke *keyError
+	if errors.As(err, &ke) {
+		ive.Key = ke.key
+		ive.Err = ke.err
+	}
+
+	return ive
+}
+
+// invalidArg returns an InvalidValueError for a failed conversion of
+// a positional argument.
+func invalidArg(name, value string, err error) *InvalidValueError {
+	return &InvalidValueError{
+		Arg:   name,
+		Value: value,
+		Err:   err,
+	}
+}
+
+// ConfigError signals a problem with an entry in a configuration file. File
+// and Line locate the entry. ConfigError wraps the underlying error, which may
+// be [ErrConfigSyntax], [*UnknownOptionError], [ErrMissingValue], or
+// [*InvalidValueError].
+type ConfigError struct {
+	Err  error
+	File string
+	Line int
+}
+
+func (e *ConfigError) Error() string {
+	// The wrapped error may carry its own "opts: " prefix.
+	msg := strings.TrimPrefix(e.Err.Error(), "opts: ")
+
+	return fmt.Sprintf("opts: %s:%d: %s", e.File, e.Line, msg)
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// ResponseFileError signals that a response file could not be expanded. File
+// names the offending file, and Err holds the underlying error, which may be
+// an error from reading the file, [ErrResponseFileDepth], or a syntax error.
+type ResponseFileError struct {
+	Err  error
+	File string
+}
+
+func (e *ResponseFileError) Error() string {
+	return fmt.Sprintf("opts: response file %s: %v", e.File, e.Err)
+}
+
+func (e *ResponseFileError) Unwrap() error {
+	return e.Err
+}
@@ -33,3 +33,31 @@ func Example_typical() {
 	// Config: {rcfile:caser.ini convention:camel strictness:5 dryRun:true write:false}
 	// Remaining args: [-awful-filename.txt file2.go]
 }
+
+func Example_usage() {
+	cfg := struct {
+		rcfile     string
+		convention string
+		strictness uint
+		verbosity  uint
+		dryRun     bool
+	}{}
+
+	og := NewGroup("caser")
+	og.String(&cfg.rcfile, "rcfile", "caser.ini", Help("read settings from this file"))
+	og.String(&cfg.convention, "convention", "camel", Help("naming convention to enforce"))
+	og.Uint(&cfg.strictness, "strictness", 3, Help("how strictly to check names"))
+	og.UintZero(&cfg.verbosity, "verbosity")
+	og.Bool(&cfg.dryRun, "dry-run", Help("report problems without fixing them"))
+
+	og.Usage(os.Stdout)
+	// Output:
+	// Usage: caser [options]
+	//
+	// Options:
+	//   --convention string  naming convention to enforce (default "camel")
+	//   --dry-run            report problems without fixing them
+	//   --rcfile string      read settings from this file (default "caser.ini")
+	//   --strictness uint    how strictly to check names (default 3)
+	//   --verbosity uint
+}
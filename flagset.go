@@ -0,0 +1,113 @@
+package opts
+
+import (
+	"flag"
+	"strconv"
+)
+
+// ImportFlagSet defines an option in the Group for every flag in fs, in
+// the order that [flag.FlagSet.VisitAll] visits them. Each option shares its
+// [flag.Value] with the flag, so parsing either one updates the same
+// variable. Flags whose values have an IsBoolFlag method that returns true
+// become switches. The flag's usage string becomes the option's help text,
+// and a name in back quotes becomes its [Placeholder], as with
+// [flag.UnquoteUsage], unless the option is a switch. ImportFlagSet will panic
+// if a flag's name is not valid for opts or repeats an existing option.
+//
+// To add a single [flag.Value] to a Group, pass it to [*Group.Var].
+func (g *Group) ImportFlagSet(fs *flag.FlagSet) {
+	fs.VisitAll(func(f *flag.Flag) {
+		placeholder, usage := flag.UnquoteUsage(f)
+
+		// UnquoteUsage finds a placeholder even for a boolean flag, but
+		// switches have none.
+		mods := []Modifier{Help(usage)}
+		if placeholder != "" && !isBoolFlag(f.Value) {
+			mods = append(mods, Placeholder(placeholder))
+		}
+
+		g.Var(f.Value, f.Name, mods...)
+	})
+}
+
+// ExportFlagSet defines a flag in fs for every option in the Group, including
+// one for each alias and one for the "no-" form of each negatable boolean.
+// Booleans and counters become boolean flags. Values that fs parses are
+// recorded as coming from the command line, so [*Group.Source] and
+// [*Group.IsSet] report them as usual, but the Group's own constraints and
+// required options are not checked. ExportFlagSet will panic if a name
+// repeats a flag that is already defined in fs.
+func (g *Group) ExportFlagSet(fs *flag.FlagSet) {
+	for _, name := range g.sortedNames() {
+		o := g.opts[name]
+		def := o.defValue
+		if o.defZero {
+			def = ""
+		}
+
+		for _, n := range o.names() {
+			fs.Var(&flagValue{o: o}, n, o.help)
+			setDefault(fs, n, def)
+
+			if o.negatable {
+				fs.Var(&flagValue{o: o, negated: true}, "no-"+n, "")
+				setDefault(fs, "no-"+n, "")
+			}
+		}
+	}
+}
+
+// setDefault records the option's default as the named flag's default, since
+// [flag.FlagSet.Var] takes the current value instead, which may already come
+// from parsing. An empty def hides the default, because
+// [flag.FlagSet.PrintDefaults] omits one that matches the String of a zero
+// flagValue, which is always empty.
+func setDefault(fs *flag.FlagSet, name, def string) {
+	fs.Lookup(name).DefValue = def
+}
+
+// A flagValue adapts an option to the [flag.Value] interface. A negated
+// flagValue stands for the "no-" form of a negatable boolean.
+type flagValue struct {
+	o       *opt
+	negated bool
+}
+
+func (f *flagValue) Set(s string) error {
+	switch {
+	case f.negated:
+		b, err := toBool(s)
+		if err != nil {
+			return err
+		}
+		return f.o.set(strconv.FormatBool(!b), SourceCommandLine)
+	case f.o.isCounter && s == "true":
+		f.o.increment(SourceCommandLine)
+		return nil
+	default:
+		return f.o.set(s, SourceCommandLine)
+	}
+}
+
+// String and IsBoolFlag must allow a zero flagValue, since the flag package
+// calls String on one to find out whether a flag's default is the zero value.
+// See setDefault.
+func (f *flagValue) String() string {
+	if f.o == nil {
+		return ""
+	}
+
+	s := f.o.value.String()
+	if f.negated {
+		b, err := toBool(s)
+		if err == nil {
+			s = strconv.FormatBool(!b)
+		}
+	}
+
+	return s
+}
+
+func (f *flagValue) IsBoolFlag() bool {
+	return f.o != nil && (f.o.isBool || f.o.isCounter)
+}
@@ -0,0 +1,291 @@
+package opts_test
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func TestImportFlagSet(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args        []string
+		wantName    string
+		wantWait    time.Duration
+		wantVerbose bool
+	}{
+		"defaults": {
+			args:     []string{},
+			wantName: "anon",
+			wantWait: time.Second,
+		},
+		"values given": {
+			args:        []string{"--name", "x", "--wait=5m", "--verbose"},
+			wantName:    "x",
+			wantWait:    5 * time.Minute,
+			wantVerbose: true,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				name    string
+				wait    time.Duration
+				verbose bool
+			)
+			fs := flag.NewFlagSet("test-flag", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.StringVar(&name, "name", "anon", "a `user` name")
+			fs.DurationVar(&wait, "wait", time.Second, "how long to wait")
+			fs.BoolVar(&verbose, "verbose", false, "enable `verbose` output")
+
+			og := opts.NewGroup("test-flag")
+			og.ImportFlagSet(fs)
+
+			if err := og.Parse(tc.args); err != nil {
+				t.Fatalf("og.Parse(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if name != tc.wantName {
+				t.Errorf("og.Parse(%v) assigns %q to name; want %q", tc.args, name, tc.wantName)
+			}
+			if wait != tc.wantWait {
+				t.Errorf("og.Parse(%v) assigns %v to wait; want %v", tc.args, wait, tc.wantWait)
+			}
+			if verbose != tc.wantVerbose {
+				t.Errorf("og.Parse(%v) assigns %t to verbose; want %t", tc.args, verbose, tc.wantVerbose)
+			}
+		})
+	}
+}
+
+func TestImportFlagSetErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args      []string
+		errWanted error
+	}{
+		"invalid value": {
+			args:      []string{"--wait", "soon"},
+			errWanted: &opts.InvalidValueError{},
+		},
+		"switch with value": {
+			args:      []string{"--verbose=true"},
+			errWanted: opts.ErrBooleanWithValue,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				name    string
+				wait    time.Duration
+				verbose bool
+			)
+			fs := flag.NewFlagSet("test-flag", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.StringVar(&name, "name", "anon", "a `user` name")
+			fs.DurationVar(&wait, "wait", time.Second, "how long to wait")
+			fs.BoolVar(&verbose, "verbose", false, "be verbose")
+
+			og := opts.NewGroup("test-flag")
+			og.ImportFlagSet(fs)
+
+			err := og.Parse(tc.args)
+
+			var ive *opts.InvalidValueError
+			if errors.As(tc.errWanted, &ive) {
+				if !errors.As(err, &ive) {
+					t.Errorf("og.Parse(%v) returns err == %v; want InvalidValueError", tc.args, err)
+				}
+				return
+			}
+
+			if !errors.Is(err, tc.errWanted) {
+				t.Errorf("og.Parse(%v) returns err == %v; want %v", tc.args, err, tc.errWanted)
+			}
+		})
+	}
+}
+
+func TestImportFlagSetUsage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		name    string
+		wait    time.Duration
+		verbose bool
+	)
+	fs := flag.NewFlagSet("test-flag", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&name, "name", "anon", "a `user` name")
+	fs.DurationVar(&wait, "wait", time.Second, "how long to wait")
+	fs.BoolVar(&verbose, "verbose", false, "be verbose")
+
+	og := opts.NewGroup("test-flag")
+	og.ImportFlagSet(fs)
+
+	var b strings.Builder
+	og.Usage(&b)
+
+	want := "Usage: test-flag [options]\n\nOptions:\n" +
+		"  --name user      a user name (default anon)\n" +
+		"  --verbose        be verbose\n" +
+		"  --wait duration  how long to wait (default 1s)\n"
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("og.Usage(); (-want +got):\n%s", diff)
+	}
+}
+
+func TestExportFlagSet(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args          []string
+		wantName      string
+		wantInclude   []string
+		wantVerbosity int
+		wantColor     bool
+		wantForce     bool
+	}{
+		"defaults": {
+			args:      []string{},
+			wantName:  "anon",
+			wantColor: true,
+		},
+		"values given": {
+			args:        []string{"-name", "x", "-include", "a", "-include", "b", "-f"},
+			wantName:    "x",
+			wantInclude: []string{"a", "b"},
+			wantColor:   true,
+			wantForce:   true,
+		},
+		"counter and negation": {
+			args:          []string{"-v", "-v", "-verbose", "-no-color"},
+			wantName:      "anon",
+			wantVerbosity: 3,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				name      string
+				include   []string
+				verbosity int
+				color     bool
+				force     bool
+			)
+			og := opts.NewGroup("test-flag")
+			og.String(&name, "name", "anon")
+			og.StringSliceZero(&include, "include")
+			og.Counter(&verbosity, "verbose", opts.Alias("v"))
+			og.NegatableBool(&color, "color", true)
+			og.Bool(&force, "force", opts.Alias("f"))
+
+			fs := flag.NewFlagSet("test-flag", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			og.ExportFlagSet(fs)
+
+			if err := fs.Parse(tc.args); err != nil {
+				t.Fatalf("fs.Parse(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if name != tc.wantName {
+				t.Errorf("fs.Parse(%v) assigns %q to name; want %q", tc.args, name, tc.wantName)
+			}
+			if diff := cmp.Diff(tc.wantInclude, include); diff != "" {
+				t.Errorf("fs.Parse(%v) include; (-want +got):\n%s", tc.args, diff)
+			}
+			if verbosity != tc.wantVerbosity {
+				t.Errorf("fs.Parse(%v) assigns %d to verbosity; want %d", tc.args, verbosity, tc.wantVerbosity)
+			}
+			if color != tc.wantColor {
+				t.Errorf("fs.Parse(%v) assigns %t to color; want %t", tc.args, color, tc.wantColor)
+			}
+			if force != tc.wantForce {
+				t.Errorf("fs.Parse(%v) assigns %t to force; want %t", tc.args, force, tc.wantForce)
+			}
+
+			if tc.wantName != "anon" && !og.IsSet("name") {
+				t.Errorf("og.IsSet(%q) = false; want true", "name")
+			}
+		})
+	}
+}
+
+func TestExportFlagSetInvalidValue(t *testing.T) {
+	t.Parallel()
+
+	var n int
+	og := opts.NewGroup("test-flag")
+	og.IntZero(&n, "n")
+
+	fs := flag.NewFlagSet("test-flag", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	og.ExportFlagSet(fs)
+
+	args := []string{"-n", "many"}
+	if err := fs.Parse(args); err == nil {
+		t.Errorf("fs.Parse(%v) returns err == nil; want error", args)
+	}
+}
+
+func TestExportFlagSetDefaults(t *testing.T) {
+	t.Parallel()
+
+	var (
+		name  string
+		force bool
+	)
+	og := opts.NewGroup("test-flag")
+	og.String(&name, "name", "anon", opts.Help("a user name"))
+	og.Bool(&force, "force")
+
+	fs := flag.NewFlagSet("test-flag", flag.ContinueOnError)
+	og.ExportFlagSet(fs)
+
+	var b strings.Builder
+	fs.SetOutput(&b)
+	fs.PrintDefaults()
+
+	want := "  -force\n    \t\n  -name value\n    \ta user name (default anon)\n"
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("fs.PrintDefaults(); (-want +got):\n%s", diff)
+	}
+}
+
+func TestExportFlagSetAfterParse(t *testing.T) {
+	t.Parallel()
+
+	var name string
+	og := opts.NewGroup("test-flag")
+	og.String(&name, "name", "anon")
+
+	args := []string{"--name", "x"}
+	if err := og.Parse(args); err != nil {
+		t.Fatalf("og.Parse(%v) returns err == %v; want nil", args, err)
+	}
+
+	fs := flag.NewFlagSet("test-flag", flag.ContinueOnError)
+	og.ExportFlagSet(fs)
+
+	if got := fs.Lookup("name").DefValue; got != "anon" {
+		t.Errorf("fs.Lookup(%q).DefValue = %q; want %q", "name", got, "anon")
+	}
+}
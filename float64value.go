@@ -8,7 +8,7 @@ import (
 // The argument f points to a float64 variable that will store the value of the
 // option. Float64 will panic if name is not valid or repeats an existing
 // option.
-func (g *Group) Float64(f *float64, name string, defValue float64) {
+func (g *Group) Float64(f *float64, name string, defValue float64, mods ...Modifier) {
 	if err := validateName("Float64", name); err != nil {
 		panic(err)
 	}
@@ -19,19 +19,17 @@ func (g *Group) Float64(f *float64, name string, defValue float64) {
 			ptr:     f,
 			convert: toFloat64,
 		},
-		name:   name,
-		isBool: false,
+		name:     name,
+		typeName: "float64",
+		isBool:   false,
 	}
 
-	if err := g.optAlreadySet(name); err != nil {
-		panic(err)
-	}
-	g.opts[name] = opt
+	g.addOpt("Float64", opt, mods)
 }
 
 // Float64Zero is like Float64 but with a default value of 0.0.
-func (g *Group) Float64Zero(f *float64, name string) {
-	g.Float64(f, name, 0.0)
+func (g *Group) Float64Zero(f *float64, name string, mods ...Modifier) {
+	g.Float64(f, name, 0.0, mods...)
 }
 
 func toFloat64(s string) (float64, error) {
@@ -3,6 +3,7 @@ package opts
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -43,13 +44,163 @@ func validateName(funcName, optName string) error {
 }
 
 func (g *Group) optAlreadySet(name string) error {
-	if _, exists := g.opts[name]; exists {
+	if _, _, exists := g.lookup(name); exists {
 		return fmt.Errorf("opts: --%s already set", name)
 	}
 
 	return nil
 }
 
+// lookup finds the option for name. If name is "no-" followed by the name of
+// a negatable boolean, lookup returns that option and reports that it is
+// negated.
+func (g *Group) lookup(name string) (o *opt, negated, ok bool) {
+	if o, ok = g.opts[name]; ok {
+		return o, false, true
+	}
+
+	if rest, found := strings.CutPrefix(name, "no-"); found {
+		if o, ok = g.opts[rest]; ok && o.negatable {
+			return o, true, true
+		}
+	}
+
+	return nil, false, false
+}
+
+// addOpt applies mods to o and registers o with the Group. The default value
+// is recorded after the modifiers run, so the caller must assign the default
+// to o's pointer before calling addOpt.
+func (g *Group) addOpt(funcName string, o *opt, mods []Modifier) {
+	for _, mod := range mods {
+		if err := mod(o); err != nil {
+			panic(fmt.Errorf("opts: %s: --%s: %w", funcName, o.name, err))
+		}
+	}
+
+	if err := o.checkImplicit(); err != nil {
+		panic(fmt.Errorf("opts: %s: --%s: %w", funcName, o.name, err))
+	}
+
+	o.defValue = o.value.String()
+	if z, ok := o.value.(interface{ isZero() bool }); ok {
+		o.defZero = z.isZero()
+	}
+
+	for _, name := range o.names() {
+		if err := g.optAlreadySet(name); err != nil {
+			panic(err)
+		}
+
+		if o.negatable {
+			if err := g.optAlreadySet("no-" + name); err != nil {
+				panic(err)
+			}
+		}
+
+		g.opts[name] = o
+	}
+}
+
+// checkImplicit reports whether an option's implicit value, if it has one,
+// converts to the option's type. It leaves the option's value and source as it
+// found them.
+func (o *opt) checkImplicit() error {
+	if !o.hasImplicit {
+		return nil
+	}
+
+	src := o.source
+	restore := o.value.save()
+	defer func() {
+		restore()
+		o.source = src
+	}()
+
+	if err := o.set(o.implicit, SourceCommandLine); err != nil {
+		return fmt.Errorf("invalid implicit value %q: %w", o.implicit, err)
+	}
+
+	return nil
+}
+
+// resolve finds the option for a name given on the command line, allowing
+// for abbreviations if they are on.
+func (g *Group) resolve(name string) (o *opt, negated bool, err error) {
+	if o, negated, ok := g.lookup(name); ok {
+		return o, negated, nil
+	}
+
+	if g.abbreviations && name != "" {
+		return g.lookupPrefix(name)
+	}
+
+	return nil, false, g.unknownOption(name)
+}
+
+// lookupPrefix finds the one option whose name, or whose negated name, begins
+// with prefix.
+func (g *Group) lookupPrefix(prefix string) (*opt, bool, error) {
+	type target struct {
+		o       *opt
+		negated bool
+	}
+
+	var candidates []string
+	targets := make(map[target]bool)
+
+	for _, name := range g.sortedKeys() {
+		o := g.opts[name]
+		if strings.HasPrefix(name, prefix) {
+			candidates = append(candidates, name)
+			targets[target{o, false}] = true
+		}
+		if o.negatable && strings.HasPrefix("no-"+name, prefix) {
+			candidates = append(candidates, "no-"+name)
+			targets[target{o, true}] = true
+		}
+	}
+
+	switch len(targets) {
+	case 0:
+		return nil, false, g.unknownOption(prefix)
+	case 1:
+		for t := range targets {
+			return t.o, t.negated, nil
+		}
+	}
+
+	slices.Sort(candidates)
+
+	return nil, false, &AmbiguousOptionError{Name: prefix, Candidates: candidates}
+}
+
+// sortedNames returns the canonical names of all options in the Group in
+// sorted order. Aliases are left out, so each option appears once.
+func (g *Group) sortedNames() []string {
+	names := make([]string, 0, len(g.opts))
+	for name, o := range g.opts {
+		if name == o.name {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// sortedKeys returns every name registered in the Group, aliases included,
+// in sorted order.
+func (g *Group) sortedKeys() []string {
+	names := make([]string, 0, len(g.opts))
+	for name := range g.opts {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
 // This function returns bare errors since the caller will give them context.
 func numError(err error) error {
 	var ne *strconv.NumError
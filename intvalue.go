@@ -7,7 +7,7 @@ import (
 // Int defines an int option with the specified name and value. The argument
 // i points to an int variable that will store the value of the option. Int
 // will panic if name is not valid or repeats an existing option.
-func (g *Group) Int(i *int, name string, defValue int) {
+func (g *Group) Int(i *int, name string, defValue int, mods ...Modifier) {
 	if err := validateName("Int", name); err != nil {
 		panic(err)
 	}
@@ -18,19 +18,17 @@ func (g *Group) Int(i *int, name string, defValue int) {
 			ptr:     i,
 			convert: toInt,
 		},
-		name:   name,
-		isBool: false,
+		name:     name,
+		typeName: "int",
+		isBool:   false,
 	}
 
-	if err := g.optAlreadySet(name); err != nil {
-		panic(err)
-	}
-	g.opts[name] = opt
+	g.addOpt("Int", opt, mods)
 }
 
 // IntZero is like Int but with a default value of 0.
-func (g *Group) IntZero(i *int, name string) {
-	g.Int(i, name, 0)
+func (g *Group) IntZero(i *int, name string, mods ...Modifier) {
+	g.Int(i, name, 0, mods...)
 }
 
 func toInt(s string) (int, error) {
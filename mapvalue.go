@@ -0,0 +1,193 @@
+package opts
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// A KeyPolicy tells a map option what to do when a key repeats.
+type KeyPolicy int
+
+const (
+	// KeepLast replaces the earlier value for a repeated key. This is the
+	// default.
+	KeepLast KeyPolicy = iota
+	// KeepFirst ignores later values for a repeated key.
+	KeepFirst
+	// RejectDuplicates treats a repeated key as an error.
+	RejectDuplicates
+)
+
+// A keyError records which key of a map option failed to convert.
+type keyError struct {
+	err error
+	key string
+}
+
+func (e *keyError) Error() string {
+	return fmt.Sprintf("key %q: %v", e.key, e.err)
+}
+
+func (e *keyError) Unwrap() error {
+	return e.err
+}
+
+// A mapValue collects "key=value" pairs for a repeatable option. It converts
+// values with the same functions as value[T], but each set adds one key to the
+// map rather than replacing it, as sliceValue does for slices.
+type mapValue[V any] struct {
+	ptr     *map[string]V
+	convert func(string) (V, error)
+	policy  KeyPolicy
+}
+
+func (v *mapValue[V]) set(s string) error {
+	key, str, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return errors.New("value must have the form key=value")
+	}
+
+	val, err := v.convert(str)
+	if err != nil {
+		return &keyError{key: key, err: err}
+	}
+
+	if *v.ptr == nil {
+		*v.ptr = make(map[string]V)
+	}
+
+	if _, exists := (*v.ptr)[key]; exists {
+		switch v.policy {
+		case KeepFirst:
+			return nil
+		case RejectDuplicates:
+			return &keyError{key: key, err: ErrDuplicateKey}
+		default:
+			// KeepLast replaces the value below.
+		}
+	}
+
+	(*v.ptr)[key] = val
+
+	return nil
+}
+
+// reset discards the current map rather than clearing it, so a default map
+// passed in by the caller is never modified.
+func (v *mapValue[V]) reset() {
+	*v.ptr = nil
+}
+
+// save copies the map, since set adds to it in place.
+func (v *mapValue[V]) save() func() {
+	saved := maps.Clone(*v.ptr)
+	return func() { *v.ptr = saved }
+}
+
+func (v *mapValue[V]) setKeyPolicy(p KeyPolicy) {
+	v.policy = p
+}
+
+func (v *mapValue[V]) String() string {
+	return fmt.Sprint(*v.ptr)
+}
+
+func (v *mapValue[V]) isZero() bool {
+	return len(*v.ptr) == 0
+}
+
+// defineMap defines a repeatable "key=value" option for the caller named
+// funcName.
+func defineMap[V any](g *Group, funcName string, p *map[string]V, name string, defValue map[string]V, convert func(string) (V, error), typeName string, mods []Modifier) {
+	if err := validateName(funcName, name); err != nil {
+		panic(err)
+	}
+
+	*p = defValue
+	opt := &opt{
+		value: &mapValue[V]{
+			ptr:     p,
+			convert: convert,
+		},
+		name:       name,
+		typeName:   "key=" + typeName,
+		isBool:     false,
+		repeatable: true,
+	}
+
+	g.addOpt(funcName, opt, mods)
+}
+
+// StringMap defines a repeatable option that collects "key=value" pairs,
+// such as "--label env=prod --label team=infra", into a map[string]string.
+// The argument m points to the map variable, and defValue is its default.
+// The first pair from the command line (or from any other source) replaces
+// the default map rather than adding to it; the default map itself is never
+// modified. A repeated key replaces the earlier value unless the
+// [DuplicateKeys] modifier says otherwise. StringMap will panic if name is
+// not valid or repeats an existing option.
+func (g *Group) StringMap(m *map[string]string, name string, defValue map[string]string, mods ...Modifier) {
+	defineMap(g, "StringMap", m, name, defValue, toString, "string", mods)
+}
+
+// StringMapZero is like StringMap but with a default value of nil.
+func (g *Group) StringMapZero(m *map[string]string, name string, mods ...Modifier) {
+	g.StringMap(m, name, nil, mods...)
+}
+
+// IntMap is like [*Group.StringMap] but with int values.
+func (g *Group) IntMap(m *map[string]int, name string, defValue map[string]int, mods ...Modifier) {
+	defineMap(g, "IntMap", m, name, defValue, toInt, "int", mods)
+}
+
+// IntMapZero is like IntMap but with a default value of nil.
+func (g *Group) IntMapZero(m *map[string]int, name string, mods ...Modifier) {
+	g.IntMap(m, name, nil, mods...)
+}
+
+// UintMap is like [*Group.StringMap] but with uint values.
+func (g *Group) UintMap(m *map[string]uint, name string, defValue map[string]uint, mods ...Modifier) {
+	defineMap(g, "UintMap", m, name, defValue, toUint, "uint", mods)
+}
+
+// UintMapZero is like UintMap but with a default value of nil.
+func (g *Group) UintMapZero(m *map[string]uint, name string, mods ...Modifier) {
+	g.UintMap(m, name, nil, mods...)
+}
+
+// Float64Map is like [*Group.StringMap] but with float64 values.
+func (g *Group) Float64Map(m *map[string]float64, name string, defValue map[string]float64, mods ...Modifier) {
+	defineMap(g, "Float64Map", m, name, defValue, toFloat64, "float64", mods)
+}
+
+// Float64MapZero is like Float64Map but with a default value of nil.
+func (g *Group) Float64MapZero(m *map[string]float64, name string, mods ...Modifier) {
+	g.Float64Map(m, name, nil, mods...)
+}
+
+// DurationMap is like [*Group.StringMap] but with time.Duration values. See
+// [*Group.Duration] for the format of each value.
+func (g *Group) DurationMap(m *map[string]time.Duration, name string, defValue map[string]time.Duration, mods ...Modifier) {
+	defineMap(g, "DurationMap", m, name, defValue, time.ParseDuration, "duration", mods)
+}
+
+// DurationMapZero is like DurationMap but with a default value of nil.
+func (g *Group) DurationMapZero(m *map[string]time.Duration, name string, mods ...Modifier) {
+	g.DurationMap(m, name, nil, mods...)
+}
+
+// DateMap is like [*Group.StringMap] but with civil.Date values. See
+// [*Group.Date] for the format of each value.
+func (g *Group) DateMap(m *map[string]civil.Date, name string, defValue map[string]civil.Date, mods ...Modifier) {
+	defineMap(g, "DateMap", m, name, defValue, civil.ParseDate, "date", mods)
+}
+
+// DateMapZero is like DateMap but with a default value of nil.
+func (g *Group) DateMapZero(m *map[string]civil.Date, name string, mods ...Modifier) {
+	g.DateMap(m, name, nil, mods...)
+}
@@ -0,0 +1,147 @@
+package opts
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// A Modifier adjusts an option as it is defined. Every option definition
+// method accepts any number of modifiers after its required arguments. E.g.,
+//
+//	og.String(&cfg.rcfile, "rcfile", "caser.ini", opts.Help("read settings from this file"))
+//
+// Definition methods panic if a modifier does not apply to the option.
+type Modifier func(*opt) error
+
+// Help sets the help text shown for an option by [*Group.Usage].
+func Help(text string) Modifier {
+	return func(o *opt) error {
+		o.help = text
+		return nil
+	}
+}
+
+// Alias gives an option one or more other names. Users may give any of the
+// names on the command line or in a configuration file, and all of them share
+// one value, one source, and one entry in help output. Errors name the option
+// by the name it was defined with. E.g.,
+//
+//	og.Bool(&cfg.help, "help", opts.Alias("h"))
+//
+// Aliases must be valid names, and they must not repeat the name of another
+// option or alias.
+func Alias(names ...string) Modifier {
+	return func(o *opt) error {
+		for _, name := range names {
+			if !isValidName(name) {
+				return fmt.Errorf("invalid alias: %s", name)
+			}
+		}
+		o.aliases = append(o.aliases, names...)
+		return nil
+	}
+}
+
+// Placeholder sets the word that stands for an option's value in help
+// output, in place of the name of its type. E.g., with Placeholder("file"),
+// an option shows as "--output file" rather than "--output string".
+func Placeholder(name string) Modifier {
+	return func(o *opt) error {
+		if o.typeName == "" {
+			return errors.New("switches have no placeholder")
+		}
+		if name == "" {
+			return errors.New("placeholder must not be empty")
+		}
+		o.valueName = name
+		return nil
+	}
+}
+
+// Required marks an option as mandatory. After parsing, [*Group.Parse] and
+// [*Group.ParseKnown] return a [*MissingOptionsError] that lists every
+// required option that was not given a value on the command line, in the
+// environment, or in a configuration file. A configuration file counts only if
+// it is read before parsing.
+func Required() Modifier {
+	return func(o *opt) error {
+		o.required = true
+		return nil
+	}
+}
+
+// Optional marks a positional argument, such as one defined by
+// [*Group.StringArg], as one that users may leave out. Options are always
+// optional, so Optional applies only to positional arguments.
+func Optional() Modifier {
+	return func(o *opt) error {
+		if !o.positional {
+			return errors.New("only positional arguments can be optional")
+		}
+		o.optional = true
+		return nil
+	}
+}
+
+// Implicit gives a non-boolean option a value to use when it appears on the
+// command line without one. E.g., with Implicit("auto"), "--color" is the same
+// as "--color=auto". An option with an implicit value never consumes the next
+// argument, so users must give an explicit value with an equal sign, as in
+// "--color=always". (When clustering is on, an attached value such as
+// "-calways" also works.) The option's definition method will panic if value
+// cannot be converted to the option's type.
+func Implicit(value string) Modifier {
+	return func(o *opt) error {
+		if o.isBool || o.isCounter {
+			return errors.New("switches cannot have an implicit value")
+		}
+		o.implicit = value
+		o.hasImplicit = true
+		return nil
+	}
+}
+
+// Env binds an option to the environment variable with the specified name.
+// If the variable is set when the Group is parsed, its value is assigned to
+// the option before the command line is parsed. As a result, values given on
+// the command line take precedence over values from the environment. A boolean
+// option's variable must be "true" or "false".
+func Env(name string) Modifier {
+	return func(o *opt) error {
+		if name == "" || strings.ContainsAny(name, "=\x00") {
+			return fmt.Errorf("invalid environment variable name: %q", name)
+		}
+		o.envVar = name
+		return nil
+	}
+}
+
+// Split makes a repeatable option, such as one defined by
+// [*Group.StringSlice], also split each value on sep. E.g., with Split(","),
+// "--include a,b" is the same as "--include a --include b".
+func Split(sep string) Modifier {
+	return func(o *opt) error {
+		if !o.repeatable {
+			return errors.New("cannot split values of a non-repeatable option")
+		}
+		if sep == "" {
+			return errors.New("separator must not be empty")
+		}
+		o.sep = sep
+		return nil
+	}
+}
+
+// DuplicateKeys sets what a map option, such as one defined by
+// [*Group.StringMap], does when a key repeats.
+func DuplicateKeys(p KeyPolicy) Modifier {
+	return func(o *opt) error {
+		kp, ok := o.value.(interface{ setKeyPolicy(KeyPolicy) })
+		if !ok {
+			return errors.New("duplicate key policy requires a map option")
+		}
+		kp.setKeyPolicy(p)
+		return nil
+	}
+}
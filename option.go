@@ -0,0 +1,85 @@
+package opts
+
+import "slices"
+
+// An Option describes an option defined in a [Group]. An Option is a snapshot:
+// it does not change when the Group is parsed, and changing it does not
+// affect the Group.
+type Option struct {
+	// Name is the name under which the option was defined, and Aliases
+	// lists its other names. See [Alias].
+	Name    string
+	Aliases []string
+
+	// Type names the type of the option's value as help output shows it,
+	// such as "int", "duration", or "key=string". Repeatable options give the
+	// type of one value. Booleans and counters report "bool" and "counter".
+	Type string
+
+	// Help is the text attached with the [Help] modifier.
+	Help string
+
+	// Default is the option's default value, and Value is its current value,
+	// both rendered as strings.
+	Default string
+	Value   string
+
+	// EnvVar names the variable attached with the [Env] modifier, if any.
+	EnvVar string
+
+	// Source records where Value came from.
+	Source Source
+
+	// Required reports whether the option was defined with [Required].
+	Required bool
+}
+
+// Lookup returns a description of the named option or nil if no such option
+// is defined. The name may be an alias.
+func (g *Group) Lookup(name string) *Option {
+	o, ok := g.opts[name]
+	if !ok {
+		return nil
+	}
+
+	return o.describe()
+}
+
+// VisitAll calls fn for each option in the Group, sorted by name.
+func (g *Group) VisitAll(fn func(*Option)) {
+	for _, name := range g.sortedNames() {
+		fn(g.opts[name].describe())
+	}
+}
+
+// Visit calls fn for each option in the Group that is set, sorted by name.
+// See [*Group.IsSet].
+func (g *Group) Visit(fn func(*Option)) {
+	for _, name := range g.sortedNames() {
+		if o := g.opts[name]; o.source != SourceDefault {
+			fn(o.describe())
+		}
+	}
+}
+
+func (o *opt) describe() *Option {
+	typeName := o.typeName
+	switch {
+	case o.isBool:
+		typeName = "bool"
+	case o.isCounter:
+		typeName = "counter"
+	}
+
+	return &Option{
+		Name:     o.name,
+		Aliases:  slices.Clone(o.aliases),
+		Type:     typeName,
+		Help:     o.help,
+		Default:  o.defValue,
+		Value:    o.value.String(),
+		EnvVar:   o.envVar,
+		Source:   o.source,
+		Required: o.required,
+	}
+}
@@ -0,0 +1,143 @@
+package opts_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		name string
+		want *opts.Option
+	}{
+		"duration with help": {
+			name: "wait",
+			want: &opts.Option{
+				Name:    "wait",
+				Type:    "duration",
+				Help:    "how long to wait",
+				Default: "1s",
+				Value:   "5m0s",
+				Source:  opts.SourceCommandLine,
+			},
+		},
+		"required string with env": {
+			name: "name",
+			want: &opts.Option{
+				Name:     "name",
+				Type:     "string",
+				Value:    "x",
+				EnvVar:   "OPTS_TEST_NAME",
+				Source:   opts.SourceCommandLine,
+				Required: true,
+			},
+		},
+		"map": {
+			name: "label",
+			want: &opts.Option{
+				Name:    "label",
+				Type:    "key=string",
+				Default: "map[]",
+				Value:   "map[]",
+			},
+		},
+		"counter": {
+			name: "verbose",
+			want: &opts.Option{
+				Name:    "verbose",
+				Type:    "counter",
+				Default: "0",
+				Value:   "2",
+				Source:  opts.SourceCommandLine,
+			},
+		},
+		"bool": {
+			name: "dry-run",
+			want: &opts.Option{
+				Name:    "dry-run",
+				Type:    "bool",
+				Default: "false",
+				Value:   "false",
+			},
+		},
+		"undefined": {
+			name: "quiet",
+			want: nil,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				wait     time.Duration
+				name     string
+				labels   map[string]string
+				verbose  int
+				dryRun   bool
+				includes []string
+			)
+			og := opts.NewGroup("test-option")
+			og.Duration(&wait, "wait", time.Second, opts.Help("how long to wait"))
+			og.StringZero(&name, "name", opts.Env("OPTS_TEST_NAME"), opts.Required())
+			og.StringMapZero(&labels, "label")
+			og.Counter(&verbose, "verbose")
+			og.Bool(&dryRun, "dry-run")
+			og.StringSliceZero(&includes, "include")
+
+			args := []string{"--wait", "5m", "--name", "x", "-verbose", "-verbose"}
+			if err := og.Parse(args); err != nil {
+				t.Fatalf("og.Parse(%v) returns err == %v; want nil", args, err)
+			}
+
+			if diff := cmp.Diff(tc.want, og.Lookup(tc.name)); diff != "" {
+				t.Errorf("og.Lookup(%q); (-want +got):\n%s", tc.name, diff)
+			}
+		})
+	}
+}
+
+func TestVisit(t *testing.T) {
+	t.Parallel()
+
+	var (
+		wait     time.Duration
+		name     string
+		labels   map[string]string
+		verbose  int
+		dryRun   bool
+		includes []string
+	)
+	og := opts.NewGroup("test-option")
+	og.Duration(&wait, "wait", time.Second, opts.Help("how long to wait"))
+	og.StringZero(&name, "name", opts.Env("OPTS_TEST_NAME"), opts.Required())
+	og.StringMapZero(&labels, "label")
+	og.Counter(&verbose, "verbose")
+	og.Bool(&dryRun, "dry-run")
+	og.StringSliceZero(&includes, "include")
+
+	args := []string{"--wait", "5m", "--name", "x", "--include", "a"}
+	if err := og.Parse(args); err != nil {
+		t.Fatalf("og.Parse(%v) returns err == %v; want nil", args, err)
+	}
+
+	var all, set []string
+	og.VisitAll(func(o *opts.Option) { all = append(all, o.Name) })
+	og.Visit(func(o *opts.Option) { set = append(set, o.Name) })
+
+	wantAll := []string{"dry-run", "include", "label", "name", "verbose", "wait"}
+	if diff := cmp.Diff(wantAll, all); diff != "" {
+		t.Errorf("og.VisitAll; (-want +got):\n%s", diff)
+	}
+
+	wantSet := []string{"include", "name", "wait"}
+	if diff := cmp.Diff(wantSet, set); diff != "" {
+		t.Errorf("og.Visit; (-want +got):\n%s", diff)
+	}
+}
@@ -1,17 +1,99 @@
 package opts
 
+import (
+	"fmt"
+	"strings"
+)
+
 // An opt stores a single option.
 type opt struct {
-	value  setter
-	name   string
-	isBool bool
+	value    setter
+	name     string
+	typeName string
+	defValue string
+	help     string
+	envVar   string
+	sep      string
+	choices  []string
+	source   Source
+	isBool   bool
+	defZero  bool
+	required bool
+
+	// A counter is a switch that counts how often it appears.
+	isCounter bool
+
+	// A negatable boolean also answers to "no-" plus its name.
+	negatable bool
+
+	// An option with an implicit value uses it when it appears without one.
+	implicit    string
+	hasImplicit bool
+
+	// A repeatable option collects every value it is given.
+	repeatable bool
+
+	// Aliases are other names for the option.
+	aliases []string
+
+	// If valueName is set, help output shows it in place of typeName.
+	valueName string
+
+	// A positional argument is assigned from the arguments left after
+	// parsing, and an optional one may be absent.
+	positional bool
+	optional   bool
+}
+
+// names returns the option's canonical name followed by its aliases.
+func (o *opt) names() []string {
+	return append([]string{o.name}, o.aliases...)
+}
+
+// increment adds one to a counter and records the source of the change.
+func (o *opt) increment(src Source) {
+	if c, ok := o.value.(*counterValue); ok {
+		c.increment()
+		o.source = src
+	}
+}
+
+// set assigns value to the option and records the source of the value. The
+// first value from a new source replaces the values of a repeatable option.
+// If the option has a separator, value is split and each part is assigned in
+// turn.
+func (o *opt) set(value string, src Source) error {
+	if src != o.source {
+		if r, ok := o.value.(interface{ reset() }); ok {
+			r.reset()
+		}
+	}
+
+	values := []string{value}
+	if o.sep != "" {
+		values = strings.Split(value, o.sep)
+	}
+
+	for _, v := range values {
+		if err := o.value.set(v); err != nil {
+			return err
+		}
+	}
+
+	o.source = src
+
+	return nil
 }
 
 // Options implement the setter interface, parsing a given string and assigning
 // its value to a pointer of the option's type or returning an error if parsing
-// fails.
+// fails. String renders the current value for help output. Save records the
+// current value and returns a function that restores it, so that a failed
+// parse can be undone.
 type setter interface {
 	set(string) error
+	String() string
+	save() func()
 }
 
 type value[T any] struct {
@@ -30,12 +112,33 @@ func (v *value[T]) set(s string) error {
 	return nil
 }
 
+func (v *value[T]) String() string {
+	return fmt.Sprint(*v.ptr)
+}
+
+func (v *value[T]) save() func() {
+	saved := *v.ptr
+	return func() { *v.ptr = saved }
+}
+
+func (v *value[T]) isZero() bool {
+	var zero T
+	return fmt.Sprint(zero) == v.String()
+}
+
 // Group stores and manages a set of options.
 type Group struct {
-	opts   map[string]*opt
-	name   string
-	args   []string
-	parsed bool
+	opts          map[string]*opt
+	positionals   []*opt
+	name          string
+	args          []string
+	constraints   []constraint
+	parsed        bool
+	clustering    bool
+	interspersed  bool
+	boolValues    bool
+	responseFiles bool
+	abbreviations bool
 }
 
 // NewGroup returns a pointer to an option Group ready to use.
@@ -50,3 +153,56 @@ func NewGroup(name string) *Group {
 func (g *Group) Name() string {
 	return g.name
 }
+
+// SetClustering turns POSIX-style short option clustering on or off. It is
+// off by default.
+//
+// When clustering is on, an argument that begins with a single dash is read as
+// a cluster of single-character options, so "-vxf" is equivalent to "-v -x
+// -f". A non-boolean option in a cluster takes the rest of the argument as its
+// value, if anything remains, and the next argument otherwise. E.g., "-ofile",
+// "-n5", and "-vo file" are all valid. Options with longer names must then be
+// given with two dashes.
+func (g *Group) SetClustering(on bool) {
+	g.clustering = on
+}
+
+// SetInterspersed turns GNU-style interspersed parsing on or off. It is off by
+// default.
+//
+// By default, parsing stops at the first non-option argument. When
+// interspersed parsing is on, parsing continues past non-option arguments,
+// which are collected in order and returned by [*Group.ParseKnown] as the
+// leftover arguments. E.g., "file.go --write other.go" sets --write and leaves
+// "file.go" and "other.go". An argument of "--" still ends parsing, and every
+// argument after it is left over as is.
+func (g *Group) SetInterspersed(on bool) {
+	g.interspersed = on
+}
+
+// SetBoolValues allows or forbids explicit values for boolean options. They
+// are forbidden by default.
+//
+// By default, boolean options are pure switches, and "--name=value" is an
+// error. When explicit values are allowed, users may also give
+// "--name=true" or "--name=false". Boolean options still never consume the
+// next argument, so "--name false" sets the option to true and leaves
+// "false" as an argument.
+func (g *Group) SetBoolValues(on bool) {
+	g.boolValues = on
+}
+
+// SetAbbreviations turns abbreviated option names on or off. They are off by
+// default.
+//
+// When abbreviations are on, users may give any prefix of an option's name
+// that matches no other option. E.g., "--verb" works for "--verbosity" as
+// long as no other option begins with "verb". An exact match always wins, so
+// "--verbose" selects "verbose" even if "verbosely" also exists. If a prefix
+// matches several options, the parsing methods return an
+// [*AmbiguousOptionError] that lists them. Abbreviations apply only to names
+// given on the command line, and they never apply to clusters of short
+// options.
+func (g *Group) SetAbbreviations(on bool) {
+	g.abbreviations = on
+}
@@ -126,6 +126,16 @@ func TestDuplicateOptRegistration(t *testing.T) {
 				og.StringZero(&s, "file")
 			},
 		},
+		"duplicate string slice": {
+			first: func(og *opts.Group) {
+				var s string
+				og.StringZero(&s, "include")
+			},
+			second: func(og *opts.Group) {
+				var s []string
+				og.StringSliceZero(&s, "include")
+			},
+		},
 		"duplicate uint": {
 			first: func(og *opts.Group) {
 				var u uint
@@ -2,6 +2,7 @@ package opts
 
 import (
 	"fmt"
+	"os"
 	"strings"
 )
 
@@ -13,8 +14,9 @@ import (
 // If Parse returns without error, the Group is considered parsed and
 // subsequent calls to Parse will return [ErrAlreadyParsed].
 //
-// Parsing stops at the first non-option argument. Any remaining arguments can
-// be accessed via the returned slice of strings. Both '-' and '--' are
+// Parsing stops at the first non-option argument unless interspersed parsing
+// is on (see [*Group.SetInterspersed]). Any remaining arguments can be
+// accessed via the returned slice of strings. Both '-' and '--' are
 // considered non-option arguments, and both stop further parsing. However,
 // the returned slice will not contain '--', but it will contain '-'. (By
 // convention, many programs treat '-' as stdin, but that is up to the calling
@@ -22,7 +24,12 @@ import (
 //
 // If Parse encounters an unknown option, an option without a value, or a value
 // that cannot be parsed as its type, it returns an error and the Group remains
-// unparsed. The caller may retry with different arguments.
+// unparsed. If any options marked with [Required] have no value after
+// parsing, Parse returns [*MissingOptionsError], which lists all of them.
+// Whatever the error, Parse first restores every option to the value and
+// source it had before the call, so the caller may retry with different
+// arguments. (A value defined with [*Group.Var] is restored by passing its
+// String back to its Set method.)
 //
 // The slice passed to Parse should not include the program name. If using
 // `os.Args` directly, the caller should pass `os.Args[1:]`.
@@ -33,12 +40,15 @@ func (g *Group) Parse(args []string) error {
 		return fmt.Errorf("opts: option group %q: %w", g.name, ErrAlreadyParsed)
 	}
 
-	err := g.parse(args)
-	if err != nil {
+	restore := g.snapshot()
+
+	if err := g.parseAll(args); err != nil {
+		restore()
 		return err
 	}
 
 	if len(g.args) > 0 {
+		restore()
 		return &UnexpectedArgumentsError{Args: g.args}
 	}
 
@@ -56,8 +66,10 @@ func (g *Group) ParseKnown(args []string) ([]string, error) {
 		return []string{}, fmt.Errorf("opts: option group %q: %w", g.name, ErrAlreadyParsed)
 	}
 
-	err := g.parse(args)
-	if err != nil {
+	restore := g.snapshot()
+
+	if err := g.parseAll(args); err != nil {
+		restore()
 		return []string{}, err
 	}
 
@@ -66,6 +78,52 @@ func (g *Group) ParseKnown(args []string) ([]string, error) {
 	return g.args, nil
 }
 
+// parseAll parses args, checks the result, and assigns positional arguments.
+func (g *Group) parseAll(args []string) error {
+	if err := g.parse(args); err != nil {
+		return err
+	}
+
+	if err := g.check(); err != nil {
+		return err
+	}
+
+	var err error
+	g.args, err = g.assignArgs(g.args)
+
+	return err
+}
+
+// snapshot records the value and source of every option and positional
+// argument and returns a function that restores them. Parse and ParseKnown
+// use it to leave the Group as it was if they fail, so that callers may
+// retry.
+func (g *Group) snapshot() func() {
+	var restores []func()
+
+	record := func(o *opt) {
+		src := o.source
+		restoreValue := o.value.save()
+		restores = append(restores, func() {
+			restoreValue()
+			o.source = src
+		})
+	}
+
+	for _, name := range g.sortedNames() {
+		record(g.opts[name])
+	}
+	for _, o := range g.positionals {
+		record(o)
+	}
+
+	return func() {
+		for _, restore := range restores {
+			restore()
+		}
+	}
+}
+
 type argType int
 
 const (
@@ -114,6 +172,9 @@ func (g *Group) shouldStopParsing(arg string, remainingArgs []string) bool {
 func (g *Group) parseByArgType(arg string, args []string) ([]string, error) {
 	switch classifyArg(arg) {
 	case argSingleDashOpt:
+		if g.clustering {
+			return g.parseCluster(arg[1:], args)
+		}
 		return g.parseOpt(arg[1:], args)
 	case argDoubleDashOpt:
 		return g.parseOpt(arg[2:], args)
@@ -123,6 +184,21 @@ func (g *Group) parseByArgType(arg string, args []string) ([]string, error) {
 }
 
 func (g *Group) parse(args []string) error {
+	if err := g.parseEnv(); err != nil {
+		return err
+	}
+
+	if g.responseFiles {
+		var err error
+		if args, err = expandResponseFiles(args); err != nil {
+			return err
+		}
+	}
+
+	if g.interspersed {
+		return g.parseInterspersed(args)
+	}
+
 	g.args = args
 
 	for len(args) > 0 {
@@ -145,37 +221,128 @@ func (g *Group) parse(args []string) error {
 	return nil
 }
 
+// parseInterspersed parses every option in args, collecting non-option
+// arguments in g.args, until it reaches the end of args or "--".
+func (g *Group) parseInterspersed(args []string) error {
+	positional := make([]string, 0, len(args))
+
+	for len(args) > 0 {
+		arg := args[0]
+		args = args[1:]
+
+		switch classifyArg(arg) {
+		case argEmpty, argNoDash, argSingleDash:
+			positional = append(positional, arg)
+		case argDoubleDash:
+			positional = append(positional, args...)
+			args = nil
+		default:
+			var err error
+			args, err = g.parseByArgType(arg, args)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	g.args = positional
+
+	return nil
+}
+
+// parseEnv assigns values from the environment to options bound to an
+// environment variable. Options are visited in name order so that errors are
+// predictable.
+func (g *Group) parseEnv() error {
+	for _, name := range g.sortedNames() {
+		opt := g.opts[name]
+		if opt.envVar == "" {
+			continue
+		}
+
+		value, ok := os.LookupEnv(opt.envVar)
+		if !ok {
+			continue
+		}
+
+		if err := opt.set(value, SourceEnv); err != nil {
+			ive := invalidValue(name, value, err)
+			ive.EnvVar = opt.envVar
+			return ive
+		}
+	}
+
+	return nil
+}
+
 func (g *Group) parseOpt(arg string, args []string) ([]string, error) {
 	name, value, eqFound := strings.Cut(arg, "=")
 
-	opt, ok := g.opts[name]
-	if !ok {
-		return nil, fmt.Errorf("opts: --%s: %w", name, ErrUnknownOption)
+	opt, negated, err := g.resolve(name)
+	switch {
+	case err != nil:
+		return nil, err
+	case negated && eqFound:
+		return nil, fmt.Errorf("opts: --no-%s=%s: %w", opt.name, value, ErrBooleanWithValue)
+	case negated:
+		return args, setOpt(opt, "false")
+	case eqFound:
+		return g.parseEquals(opt, value, arg, args)
+	default:
+		return parseSpaced(opt, args)
 	}
+}
+
+// parseCluster parses a cluster of single-character options, such as "vxf".
+// Boolean options are switched on one after another. The first non-boolean
+// option takes the rest of the cluster as its value or, if nothing remains,
+// the next argument.
+func (g *Group) parseCluster(cluster string, args []string) ([]string, error) {
+	for i, r := range cluster {
+		name := string(r)
+
+		opt, ok := g.opts[name]
+		if !ok {
+			uoe := g.unknownOption(name)
+			uoe.short = true
+			return nil, uoe
+		}
+
+		switch {
+		case opt.isBool:
+			if err := setOpt(opt, "true"); err != nil {
+				return nil, err
+			}
+			continue
+		case opt.isCounter:
+			opt.increment(SourceCommandLine)
+			continue
+		}
+
+		if attached := cluster[i+len(name):]; attached != "" {
+			return args, setOpt(opt, attached)
+		}
 
-	if eqFound {
-		return parseEquals(opt, name, value, arg, args)
+		return parseSpaced(opt, args)
 	}
 
-	return parseSpaced(opt, name, args)
+	return args, nil
 }
 
-func parseEquals(opt *opt, name, value, arg string, args []string) ([]string, error) {
-	if opt.isBool {
-		return nil, fmt.Errorf("opts: --%s=%s: %w", name, value, ErrBooleanWithValue)
+// Errors from parseEquals, parseSpaced, and setOpt name the option by its
+// canonical name, even if users gave an alias or an abbreviation.
+func (g *Group) parseEquals(opt *opt, value, arg string, args []string) ([]string, error) {
+	if opt.isBool && !g.boolValues {
+		return nil, fmt.Errorf("opts: --%s=%s: %w", opt.name, value, ErrBooleanWithValue)
 	}
 
-	if err := opt.value.set(value); err != nil {
+	if err := opt.set(value, SourceCommandLine); err != nil {
 		// Distinguish no value from a bad value.
 		if value == "" {
-			return nil, fmt.Errorf("opts: --%s=: %w", name, ErrMissingValue)
+			return nil, fmt.Errorf("opts: --%s=: %w", opt.name, ErrMissingValue)
 		}
 
-		return nil, &InvalidValueError{
-			Option: name,
-			Value:  value,
-			Err:    err,
-		}
+		return nil, invalidValue(opt.name, value, err)
 	}
 
 	// A string option `--foo=` will not produce an error when calling set.
@@ -183,31 +350,40 @@ func parseEquals(opt *opt, name, value, arg string, args []string) ([]string, er
 	// string value. However, for consistency with other option types, we
 	// should return an error indicating that there is no value.
 	if value == "" && arg[len(arg)-1] == '=' {
-		return nil, fmt.Errorf("opts: --%s=: %w", name, ErrMissingValue)
+		return nil, fmt.Errorf("opts: --%s=: %w", opt.name, ErrMissingValue)
 	}
 
 	return args, nil
 }
 
-func parseSpaced(opt *opt, name string, args []string) ([]string, error) {
+func parseSpaced(opt *opt, args []string) ([]string, error) {
 	var value string
 
 	switch {
 	case opt.isBool:
 		value = "true"
+	case opt.isCounter:
+		opt.increment(SourceCommandLine)
+		return args, nil
+	case opt.hasImplicit:
+		value = opt.implicit
 	case len(args) > 0:
 		value, args = args[0], args[1:]
 	default:
-		return nil, fmt.Errorf("opts: --%s: %w", name, ErrMissingValue)
+		return nil, fmt.Errorf("opts: --%s: %w", opt.name, ErrMissingValue)
 	}
 
-	if err := opt.value.set(value); err != nil {
-		return nil, &InvalidValueError{
-			Option: name,
-			Value:  value,
-			Err:    err,
-		}
+	if err := setOpt(opt, value); err != nil {
+		return nil, err
 	}
 
 	return args, nil
 }
+
+func setOpt(opt *opt, value string) error {
+	if err := opt.set(value, SourceCommandLine); err != nil {
+		return invalidValue(opt.name, value, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package opts_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func TestParseAbbreviations(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args          []string
+		wantVerbosity int
+		wantVerbose   bool
+		wantVersion   bool
+		wantColor     bool
+		wantName      string
+	}{
+		"unique prefix": {
+			args:          []string{"--verbosi", "2"},
+			wantVerbosity: 2,
+			wantColor:     true,
+		},
+		"unique prefix with equals": {
+			args:          []string{"--verbosi=2"},
+			wantVerbosity: 2,
+			wantColor:     true,
+		},
+		"exact match wins over longer names": {
+			args:        []string{"--verbose"},
+			wantVerbose: true,
+			wantColor:   true,
+		},
+		"prefix of negation": {
+			args: []string{"--no-c"},
+		},
+		"prefix with one dash": {
+			args:        []string{"-vers"},
+			wantVersion: true,
+			wantColor:   true,
+		},
+		"full names still work": {
+			args:     []string{"--name", "x", "--no-color"},
+			wantName: "x",
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var verbosity int
+			var verbose, version, color bool
+			var name string
+
+			og := opts.NewGroup("test-abbrev")
+			og.SetAbbreviations(true)
+			og.IntZero(&verbosity, "verbosity")
+			og.Bool(&verbose, "verbose")
+			og.Bool(&version, "version")
+			og.NegatableBool(&color, "color", true)
+			og.StringZero(&name, "name")
+
+			if err := og.Parse(tc.args); err != nil {
+				t.Fatalf("og.Parse(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if verbosity != tc.wantVerbosity {
+				t.Errorf("og.Parse(%v) assigns %d to verbosity; want %d", tc.args, verbosity, tc.wantVerbosity)
+			}
+			if verbose != tc.wantVerbose {
+				t.Errorf("og.Parse(%v) assigns %t to verbose; want %t", tc.args, verbose, tc.wantVerbose)
+			}
+			if version != tc.wantVersion {
+				t.Errorf("og.Parse(%v) assigns %t to version; want %t", tc.args, version, tc.wantVersion)
+			}
+			if color != tc.wantColor {
+				t.Errorf("og.Parse(%v) assigns %t to color; want %t", tc.args, color, tc.wantColor)
+			}
+			if name != tc.wantName {
+				t.Errorf("og.Parse(%v) assigns %q to name; want %q", tc.args, name, tc.wantName)
+			}
+		})
+	}
+}
+
+func TestParseAbbreviationsAmbiguous(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args []string
+		want []string
+	}{
+		"several options": {
+			args: []string{"--verb"},
+			want: []string{"verbose", "verbosity"},
+		},
+		"option and negation": {
+			args: []string{"--n", "x"},
+			want: []string{"name", "no-color"},
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var verbosity int
+			var verbose, version, color bool
+			var name string
+
+			og := opts.NewGroup("test-abbrev")
+			og.SetAbbreviations(true)
+			og.IntZero(&verbosity, "verbosity")
+			og.Bool(&verbose, "verbose")
+			og.Bool(&version, "version")
+			og.NegatableBool(&color, "color", true)
+			og.StringZero(&name, "name")
+			err := og.Parse(tc.args)
+
+			var aoe *opts.AmbiguousOptionError
+			if !errors.As(err, &aoe) {
+				t.Fatalf("og.Parse(%v) returns %T; want AmbiguousOptionError", tc.args, err)
+			}
+
+			if diff := cmp.Diff(tc.want, aoe.Candidates); diff != "" {
+				t.Errorf("og.Parse(%v); (-want +got):\n%s", tc.args, diff)
+			}
+		})
+	}
+}
+
+func TestParseAbbreviationsUnknown(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args []string
+	}{
+		"no match":   {args: []string{"--quiet"}},
+		"empty name": {args: []string{"--=x"}},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var verbosity int
+			var verbose, version, color bool
+			var name string
+
+			og := opts.NewGroup("test-abbrev")
+			og.SetAbbreviations(true)
+			og.IntZero(&verbosity, "verbosity")
+			og.Bool(&verbose, "verbose")
+			og.Bool(&version, "version")
+			og.NegatableBool(&color, "color", true)
+			og.StringZero(&name, "name")
+
+			if err := og.Parse(tc.args); !errors.Is(err, opts.ErrUnknownOption) {
+				t.Errorf("og.Parse(%v) returns err == %v; want ErrUnknownOption", tc.args, err)
+			}
+		})
+	}
+}
+
+func TestParseAbbreviationsOff(t *testing.T) {
+	t.Parallel()
+
+	args := []string{"--verbosi", "2"}
+	var verbosity int
+	og := opts.NewGroup("test-abbrev")
+	og.IntZero(&verbosity, "verbosity")
+
+	if err := og.Parse(args); !errors.Is(err, opts.ErrUnknownOption) {
+		t.Errorf("og.Parse(%v) returns err == %v; want ErrUnknownOption", args, err)
+	}
+}
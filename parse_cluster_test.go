@@ -0,0 +1,231 @@
+package opts_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func TestParseCluster(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args        []string
+		postArgs    []string
+		wantOutput  string
+		wantCount   int
+		wantVerbose bool
+		wantExtract bool
+		wantForce   bool
+	}{
+		"separate short options": {
+			args:        []string{"-v", "-x"},
+			postArgs:    []string{},
+			wantVerbose: true,
+			wantExtract: true,
+		},
+		"clustered booleans": {
+			args:        []string{"-vx"},
+			postArgs:    []string{},
+			wantVerbose: true,
+			wantExtract: true,
+		},
+		"cluster ending in option with spaced value": {
+			args:        []string{"-vxo", "file", "rest"},
+			postArgs:    []string{"rest"},
+			wantOutput:  "file",
+			wantVerbose: true,
+			wantExtract: true,
+		},
+		"attached value": {
+			args:       []string{"-ofile", "-n5"},
+			postArgs:   []string{},
+			wantOutput: "file",
+			wantCount:  5,
+		},
+		"attached value after booleans": {
+			args:        []string{"-vn42"},
+			postArgs:    []string{},
+			wantCount:   42,
+			wantVerbose: true,
+		},
+		"attached value containing option letters": {
+			args:       []string{"-ovx"},
+			postArgs:   []string{},
+			wantOutput: "vx",
+		},
+		"long option with two dashes": {
+			args:        []string{"--force", "-v", "--n", "3"},
+			postArgs:    []string{},
+			wantCount:   3,
+			wantVerbose: true,
+			wantForce:   true,
+		},
+		"negative spaced value": {
+			args:      []string{"-n", "-3"},
+			postArgs:  []string{},
+			wantCount: -3,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var gotOutput string
+			var gotCount int
+			var gotVerbose, gotExtract, gotForce bool
+
+			og := opts.NewGroup("test-cluster")
+			og.SetClustering(true)
+			og.Bool(&gotVerbose, "v")
+			og.Bool(&gotExtract, "x")
+			og.Bool(&gotForce, "force")
+			og.StringZero(&gotOutput, "o")
+			og.IntZero(&gotCount, "n")
+
+			remaining, err := og.ParseKnown(tc.args)
+			if err != nil {
+				t.Fatalf("og.ParseKnown(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if gotOutput != tc.wantOutput {
+				t.Errorf("og.ParseKnown(%v) assigns %q to -o; want %q", tc.args, gotOutput, tc.wantOutput)
+			}
+			if gotCount != tc.wantCount {
+				t.Errorf("og.ParseKnown(%v) assigns %d to -n; want %d", tc.args, gotCount, tc.wantCount)
+			}
+			if gotVerbose != tc.wantVerbose {
+				t.Errorf("og.ParseKnown(%v) assigns %t to -v; want %t", tc.args, gotVerbose, tc.wantVerbose)
+			}
+			if gotExtract != tc.wantExtract {
+				t.Errorf("og.ParseKnown(%v) assigns %t to -x; want %t", tc.args, gotExtract, tc.wantExtract)
+			}
+			if gotForce != tc.wantForce {
+				t.Errorf("og.ParseKnown(%v) assigns %t to --force; want %t", tc.args, gotForce, tc.wantForce)
+			}
+
+			if diff := cmp.Diff(tc.postArgs, remaining); diff != "" {
+				t.Errorf("og.ParseKnown(%v) remaining; (-want +got):\n%s", tc.args, diff)
+			}
+		})
+	}
+}
+
+func TestParseClusterErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		errWanted error
+		args      []string
+	}{
+		"unknown option in cluster": {
+			args:      []string{"-vq"},
+			errWanted: opts.ErrUnknownOption,
+		},
+		"long option with one dash": {
+			args:      []string{"-force"},
+			errWanted: opts.ErrUnknownOption,
+		},
+		"missing value at end of cluster": {
+			args:      []string{"-vo"},
+			errWanted: opts.ErrMissingValue,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var output string
+			var count int
+			var verbose, force bool
+
+			og := opts.NewGroup("test-cluster")
+			og.SetClustering(true)
+			og.Bool(&verbose, "v")
+			og.Bool(&force, "force")
+			og.StringZero(&output, "o")
+			og.IntZero(&count, "n")
+
+			err := og.Parse(tc.args)
+			if !errors.Is(err, tc.errWanted) {
+				t.Errorf("og.Parse(%v) returns err == %v; want %v", tc.args, err, tc.errWanted)
+			}
+		})
+	}
+}
+
+func TestParseClusterUnknownOption(t *testing.T) {
+	t.Parallel()
+
+	var verbose bool
+	og := opts.NewGroup("test-cluster")
+	og.SetClustering(true)
+	og.Bool(&verbose, "v")
+
+	args := []string{"-vq"}
+	err := og.Parse(args)
+	if err == nil {
+		t.Fatalf("og.Parse(%v) returns err == nil; want error", args)
+	}
+
+	want := "opts: -q: unknown option"
+	if got := err.Error(); got != want {
+		t.Errorf("og.Parse(%v) returns err == %q; want %q", args, got, want)
+	}
+}
+
+func TestParseClusterInvalidValue(t *testing.T) {
+	t.Parallel()
+
+	var count int
+	var verbose bool
+	og := opts.NewGroup("test-cluster")
+	og.SetClustering(true)
+	og.Bool(&verbose, "v")
+	og.IntZero(&count, "n")
+
+	args := []string{"-vnx"}
+	err := og.Parse(args)
+	var ive *opts.InvalidValueError
+	if !errors.As(err, &ive) {
+		t.Fatalf("og.Parse(%v) returns %T; want InvalidValueError", args, err)
+	}
+
+	if ive.Value != "x" {
+		t.Errorf("og.Parse(%v) reports value %q; want %q", args, ive.Value, "x")
+	}
+}
+
+func TestUsageClustering(t *testing.T) {
+	t.Parallel()
+
+	var output string
+	var count int
+	var verbose, extract, force bool
+
+	og := opts.NewGroup("test-cluster")
+	og.SetClustering(true)
+	og.Bool(&verbose, "v")
+	og.Bool(&extract, "x")
+	og.Bool(&force, "force")
+	og.StringZero(&output, "o")
+	og.IntZero(&count, "n")
+
+	want := "Usage: test-cluster [options]\n\nOptions:\n" +
+		"  --force\n" +
+		"  -n int\n" +
+		"  -o string\n" +
+		"  -v\n" +
+		"  -x\n"
+
+	var b strings.Builder
+	og.Usage(&b)
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("og.Usage(); (-want +got):\n%s", diff)
+	}
+}
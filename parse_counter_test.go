@@ -0,0 +1,151 @@
+package opts_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/telemachus/opts"
+)
+
+func TestParseCounter(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args       []string
+		clustering bool
+		want       int
+	}{
+		"absent": {
+			args: []string{},
+			want: 0,
+		},
+		"once": {
+			args: []string{"-v"},
+			want: 1,
+		},
+		"repeated with short and long names": {
+			args: []string{"-v", "--verbose", "-v"},
+			want: 3,
+		},
+		"explicit value": {
+			args: []string{"--verbose=3"},
+			want: 3,
+		},
+		"increment after explicit value": {
+			args: []string{"--verbose=3", "-v"},
+			want: 4,
+		},
+		"does not consume next argument": {
+			args: []string{"-v", "5"},
+			want: 1,
+		},
+		"clustered": {
+			args:       []string{"-vvv"},
+			clustering: true,
+			want:       3,
+		},
+		"clustered with other switches": {
+			args:       []string{"-vqv"},
+			clustering: true,
+			want:       2,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				got   int
+				quiet bool
+			)
+			og := opts.NewGroup("test-parsing")
+			og.SetClustering(tc.clustering)
+			og.Counter(&got, "v")
+			og.Counter(&got, "verbose")
+			og.Bool(&quiet, "q")
+
+			if _, err := og.ParseKnown(tc.args); err != nil {
+				t.Fatalf("og.ParseKnown(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if got != tc.want {
+				t.Errorf("og.ParseKnown(%v) assigns %d to got; want %d", tc.args, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseCounterInvalidValue(t *testing.T) {
+	t.Parallel()
+
+	args := []string{"--verbose=lots"}
+	var got int
+	og := opts.NewGroup("test-parsing")
+	og.Counter(&got, "verbose")
+
+	err := og.Parse(args)
+	var ive *opts.InvalidValueError
+	if !errors.As(err, &ive) {
+		t.Fatalf("og.Parse(%v) returns %T; want InvalidValueError", args, err)
+	}
+}
+
+func TestParseCounterConfigThenCommandLine(t *testing.T) {
+	t.Parallel()
+
+	var got int
+	og := opts.NewGroup("test-parsing")
+	og.Counter(&got, "verbose")
+
+	config := "verbose = 2\n"
+	if err := og.ReadConfig(strings.NewReader(config), "test.ini"); err != nil {
+		t.Fatalf("og.ReadConfig(%q) returns err == %v; want nil", config, err)
+	}
+
+	args := []string{"--verbose"}
+	if err := og.Parse(args); err != nil {
+		t.Fatalf("og.Parse(%v) returns err == %v; want nil", args, err)
+	}
+
+	if got != 3 {
+		t.Errorf("og.Parse(%v) after config assigns %d to got; want 3", args, got)
+	}
+}
+
+func TestParseCounterNegativeValue(t *testing.T) {
+	t.Parallel()
+
+	args := []string{"--verbose=-4"}
+	var got int
+	og := opts.NewGroup("test-parsing")
+	og.Counter(&got, "verbose")
+
+	err := og.Parse(args)
+	var ive *opts.InvalidValueError
+	if !errors.As(err, &ive) {
+		t.Fatalf("og.Parse(%v) returns %T; want InvalidValueError", args, err)
+	}
+
+	if got != 0 {
+		t.Errorf("og.Parse(%v) assigns %d to got; want 0", args, got)
+	}
+}
+
+func TestParseCounterBareConfigKey(t *testing.T) {
+	t.Parallel()
+
+	var got int
+	og := opts.NewGroup("test-parsing")
+	og.Counter(&got, "verbose")
+
+	config := "verbose\nverbose\n"
+	if err := og.ReadConfig(strings.NewReader(config), "test.ini"); err != nil {
+		t.Fatalf("og.ReadConfig(%q) returns err == %v; want nil", config, err)
+	}
+
+	if got != 2 {
+		t.Errorf("og.ReadConfig(%q) assigns %d to got; want 2", config, got)
+	}
+}
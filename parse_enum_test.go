@@ -0,0 +1,152 @@
+package opts_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func TestParseEnum(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args []string
+		want string
+	}{
+		"default": {
+			args: []string{},
+			want: "camel",
+		},
+		"spaced value": {
+			args: []string{"--convention", "snake"},
+			want: "snake",
+		},
+		"equals value": {
+			args: []string{"--convention=kebab"},
+			want: "kebab",
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+			og := opts.NewGroup("test-parsing")
+			og.Enum(&got, "convention", "camel", []string{"camel", "snake", "kebab"})
+
+			if err := og.Parse(tc.args); err != nil {
+				t.Fatalf("og.Parse(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if got != tc.want {
+				t.Errorf("og.Parse(%v) assigns %q to got; want %q", tc.args, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseEnumInvalidValue(t *testing.T) {
+	t.Parallel()
+
+	args := []string{"--convention", "pascal"}
+	var got string
+	og := opts.NewGroup("test-parsing")
+	og.Enum(&got, "convention", "camel", []string{"camel", "snake", "kebab"})
+
+	err := og.Parse(args)
+	var ive *opts.InvalidValueError
+	if !errors.As(err, &ive) {
+		t.Fatalf("og.Parse(%v) returns %T; want InvalidValueError", args, err)
+	}
+
+	want := `value must be one of "camel", "snake", "kebab"`
+	if ive.Err.Error() != want {
+		t.Errorf("og.Parse(%v) returns Err == %q; want %q", args, ive.Err.Error(), want)
+	}
+}
+
+type level int
+
+const (
+	levelDebug level = iota
+	levelInfo
+	levelWarn
+)
+
+func TestParseEnumOf(t *testing.T) {
+	t.Parallel()
+
+	choices := map[string]level{"debug": levelDebug, "info": levelInfo, "warn": levelWarn}
+
+	var got level
+	og := opts.NewGroup("test-parsing")
+	opts.EnumOf(og, &got, "level", levelInfo, choices)
+
+	if got != levelInfo {
+		t.Fatalf("opts.EnumOf() assigns %d as default; want %d", got, levelInfo)
+	}
+
+	args := []string{"--level", "warn"}
+	if err := og.Parse(args); err != nil {
+		t.Fatalf("og.Parse(%v) returns err == %v; want nil", args, err)
+	}
+
+	if got != levelWarn {
+		t.Errorf("og.Parse(%v) assigns %d to got; want %d", args, got, levelWarn)
+	}
+}
+
+func TestEnumUsage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		convention string
+		lvl        level
+	)
+	og := opts.NewGroup("test-usage")
+	og.Enum(&convention, "convention", "camel", []string{"camel", "snake", "kebab"}, opts.Help("naming convention"))
+	opts.EnumOf(og, &lvl, "level", levelInfo, map[string]level{"debug": levelDebug, "info": levelInfo, "warn": levelWarn})
+
+	want := "Usage: test-usage [options]\n\nOptions:\n" +
+		"  --convention camel|snake|kebab  naming convention (default camel)\n" +
+		"  --level debug|info|warn         (default info)\n"
+
+	var b strings.Builder
+	og.Usage(&b)
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("og.Usage(); (-want +got):\n%s", diff)
+	}
+}
+
+func TestEnumInvalidDefinition(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		defValue string
+		choices  []string
+	}{
+		"no choices":            {defValue: "", choices: []string{}},
+		"default not a choice":  {defValue: "pascal", choices: []string{"camel", "snake"}},
+		"duplicate choices":     {defValue: "camel", choices: []string{"camel", "camel"}},
+		"zero default not used": {defValue: "", choices: []string{"camel"}},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			og := opts.NewGroup("test-parsing")
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("expected panic on invalid enum definition")
+				}
+			}()
+			var s string
+			og.Enum(&s, "convention", tc.defValue, tc.choices)
+		})
+	}
+}
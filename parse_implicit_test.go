@@ -0,0 +1,173 @@
+package opts_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func TestParseImplicit(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args       []string
+		postArgs   []string
+		want       string
+		clustering bool
+	}{
+		"absent": {
+			args:     []string{},
+			postArgs: []string{},
+			want:     "never",
+		},
+		"without value": {
+			args:     []string{"--color"},
+			postArgs: []string{},
+			want:     "auto",
+		},
+		"next argument is not consumed": {
+			args:     []string{"--color", "always"},
+			postArgs: []string{"always"},
+			want:     "auto",
+		},
+		"explicit value": {
+			args:     []string{"--color=always"},
+			postArgs: []string{},
+			want:     "always",
+		},
+		"clustered without value": {
+			args:       []string{"-c", "file"},
+			postArgs:   []string{"file"},
+			want:       "auto",
+			clustering: true,
+		},
+		"clustered with attached value": {
+			args:       []string{"-calways"},
+			postArgs:   []string{},
+			want:       "always",
+			clustering: true,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+			og := opts.NewGroup("test-parsing")
+			og.SetClustering(tc.clustering)
+			og.String(&got, "color", "never", opts.Implicit("auto"))
+			og.String(&got, "c", "never", opts.Implicit("auto"))
+
+			remaining, err := og.ParseKnown(tc.args)
+			if err != nil {
+				t.Fatalf("og.ParseKnown(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if got != tc.want {
+				t.Errorf("og.ParseKnown(%v) assigns %q to got; want %q", tc.args, got, tc.want)
+			}
+
+			if diff := cmp.Diff(tc.postArgs, remaining); diff != "" {
+				t.Errorf("og.ParseKnown(%v); (-want +got):\n%s", tc.args, diff)
+			}
+		})
+	}
+}
+
+func TestParseImplicitEnum(t *testing.T) {
+	t.Parallel()
+
+	var got string
+	og := opts.NewGroup("test-parsing")
+	og.Enum(&got, "color", "never", []string{"auto", "always", "never"}, opts.Implicit("auto"))
+
+	args := []string{"--color"}
+	if err := og.Parse(args); err != nil {
+		t.Fatalf("og.Parse(%v) returns err == %v; want nil", args, err)
+	}
+	if got != "auto" {
+		t.Errorf("og.Parse(%v) assigns %q to got; want %q", args, got, "auto")
+	}
+
+	og2 := opts.NewGroup("test-parsing")
+	og2.Enum(&got, "color", "never", []string{"auto", "always", "never"}, opts.Implicit("auto"))
+	args = []string{"--color=sometimes"}
+	var ive *opts.InvalidValueError
+	if err := og2.Parse(args); !errors.As(err, &ive) {
+		t.Errorf("og.Parse(%v) returns %T; want InvalidValueError", args, err)
+	}
+}
+
+func TestImplicitRequiresValueOption(t *testing.T) {
+	t.Parallel()
+
+	og := opts.NewGroup("test-parsing")
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic when Implicit is used with a boolean")
+		}
+	}()
+	var b bool
+	og.Bool(&b, "color", opts.Implicit("auto"))
+}
+
+func TestImplicitInvalidValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		define func(*opts.Group)
+	}{
+		"int": {
+			define: func(og *opts.Group) {
+				var n int
+				og.Int(&n, "level", 1, opts.Implicit("high"))
+			},
+		},
+		"enum": {
+			define: func(og *opts.Group) {
+				var s string
+				og.Enum(&s, "color", "never", []string{"auto", "never"}, opts.Implicit("always"))
+			},
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			og := opts.NewGroup("test-parsing")
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("expected panic on invalid implicit value")
+				}
+			}()
+			tc.define(og)
+		})
+	}
+}
+
+func TestImplicitUsage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		color string
+		level int
+	)
+	og := opts.NewGroup("test-usage")
+	og.Enum(&color, "color", "never", []string{"auto", "always", "never"}, opts.Implicit("auto"))
+	og.IntZero(&level, "level", opts.Implicit("1"))
+
+	want := "Usage: test-usage [options]\n\nOptions:\n" +
+		"  --color[=auto|always|never]  (default never)\n" +
+		"  --level[=int]\n"
+
+	var b strings.Builder
+	og.Usage(&b)
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("og.Usage(); (-want +got):\n%s", diff)
+	}
+}
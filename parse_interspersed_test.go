@@ -0,0 +1,113 @@
+package opts_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func TestParseInterspersed(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args      []string
+		postArgs  []string
+		wantWrite bool
+		wantName  string
+	}{
+		"no arguments": {
+			args:     []string{},
+			postArgs: []string{},
+		},
+		"option after positional": {
+			args:      []string{"file.go", "--write"},
+			postArgs:  []string{"file.go"},
+			wantWrite: true,
+		},
+		"options and positionals mixed": {
+			args:      []string{"a.go", "--name", "x", "b.go", "-write", "c.go"},
+			postArgs:  []string{"a.go", "b.go", "c.go"},
+			wantWrite: true,
+			wantName:  "x",
+		},
+		"single dash is positional": {
+			args:      []string{"-", "--write"},
+			postArgs:  []string{"-"},
+			wantWrite: true,
+		},
+		"double dash ends parsing": {
+			args:     []string{"a.go", "--", "--write", "b.go"},
+			postArgs: []string{"a.go", "--write", "b.go"},
+		},
+		"option value is not positional": {
+			args:     []string{"--name", "a.go", "b.go"},
+			postArgs: []string{"b.go"},
+			wantName: "a.go",
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				write bool
+				name  string
+			)
+			og := opts.NewGroup("test-parsing")
+			og.SetInterspersed(true)
+			og.Bool(&write, "write")
+			og.StringZero(&name, "name")
+
+			remaining, err := og.ParseKnown(tc.args)
+			if err != nil {
+				t.Fatalf("og.ParseKnown(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if diff := cmp.Diff(tc.postArgs, remaining); diff != "" {
+				t.Errorf("og.ParseKnown(%v); (-want +got):\n%s", tc.args, diff)
+			}
+			if write != tc.wantWrite {
+				t.Errorf("og.ParseKnown(%v) assigns %t to write; want %t", tc.args, write, tc.wantWrite)
+			}
+			if name != tc.wantName {
+				t.Errorf("og.ParseKnown(%v) assigns %q to name; want %q", tc.args, name, tc.wantName)
+			}
+		})
+	}
+}
+
+func TestParseInterspersedStrict(t *testing.T) {
+	t.Parallel()
+
+	args := []string{"a.go", "--write", "b.go"}
+	var write bool
+	og := opts.NewGroup("test-parsing")
+	og.SetInterspersed(true)
+	og.Bool(&write, "write")
+
+	err := og.Parse(args)
+
+	var uae *opts.UnexpectedArgumentsError
+	if !errors.As(err, &uae) {
+		t.Fatalf("og.Parse(%v) returns %T; want UnexpectedArgumentsError", args, err)
+	}
+
+	if diff := cmp.Diff([]string{"a.go", "b.go"}, uae.Args); diff != "" {
+		t.Errorf("og.Parse(%v); (-want +got):\n%s", args, diff)
+	}
+}
+
+func TestParseInterspersedUnknownOption(t *testing.T) {
+	t.Parallel()
+
+	args := []string{"a.go", "--force"}
+	og := opts.NewGroup("test-parsing")
+	og.SetInterspersed(true)
+
+	if _, err := og.ParseKnown(args); !errors.Is(err, opts.ErrUnknownOption) {
+		t.Errorf("og.ParseKnown(%v) returns err == %v; want ErrUnknownOption", args, err)
+	}
+}
@@ -0,0 +1,168 @@
+package opts_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func TestParseStringMap(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args []string
+		want map[string]string
+	}{
+		"no occurrences keeps default": {
+			args: []string{},
+			want: map[string]string{"env": "dev"},
+		},
+		"first occurrence replaces default": {
+			args: []string{"--label", "team=infra"},
+			want: map[string]string{"team": "infra"},
+		},
+		"repeated occurrences collect": {
+			args: []string{"--label", "env=prod", "--label=team=infra"},
+			want: map[string]string{"env": "prod", "team": "infra"},
+		},
+		"value may contain equals": {
+			args: []string{"--label", "expr=a=b"},
+			want: map[string]string{"expr": "a=b"},
+		},
+		"value may be empty": {
+			args: []string{"--label", "empty="},
+			want: map[string]string{"empty": ""},
+		},
+		"repeated key keeps last by default": {
+			args: []string{"--label", "env=dev", "--label", "env=prod"},
+			want: map[string]string{"env": "prod"},
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			defValue := map[string]string{"env": "dev"}
+			var got map[string]string
+			og := opts.NewGroup("test-parsing")
+			og.StringMap(&got, "label", defValue)
+
+			if err := og.Parse(tc.args); err != nil {
+				t.Fatalf("og.Parse(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("og.Parse(%v); (-want +got):\n%s", tc.args, diff)
+			}
+
+			if diff := cmp.Diff(map[string]string{"env": "dev"}, defValue); diff != "" {
+				t.Errorf("og.Parse(%v) modifies default; (-want +got):\n%s", tc.args, diff)
+			}
+		})
+	}
+}
+
+func TestParseMapDuplicateKeys(t *testing.T) {
+	t.Parallel()
+
+	args := []string{"--limit", "a=1", "--limit", "a=2"}
+
+	var first map[string]int
+	og := opts.NewGroup("test-parsing")
+	og.IntMapZero(&first, "limit", opts.DuplicateKeys(opts.KeepFirst))
+	if err := og.Parse(args); err != nil {
+		t.Fatalf("og.Parse(%v) returns err == %v; want nil", args, err)
+	}
+	if diff := cmp.Diff(map[string]int{"a": 1}, first); diff != "" {
+		t.Errorf("og.Parse(%v) with KeepFirst; (-want +got):\n%s", args, diff)
+	}
+
+	var rejected map[string]int
+	og2 := opts.NewGroup("test-parsing")
+	og2.IntMapZero(&rejected, "limit", opts.DuplicateKeys(opts.RejectDuplicates))
+	err := og2.Parse(args)
+	if !errors.Is(err, opts.ErrDuplicateKey) {
+		t.Fatalf("og.Parse(%v) with RejectDuplicates returns err == %v; want ErrDuplicateKey", args, err)
+	}
+	var ive *opts.InvalidValueError
+	if !errors.As(err, &ive) || ive.Key != "a" {
+		t.Errorf("og.Parse(%v) with RejectDuplicates returns err == %v; want InvalidValueError for key %q", args, err, "a")
+	}
+}
+
+func TestParseMapSplit(t *testing.T) {
+	t.Parallel()
+
+	args := []string{"--timeout", "read=1s,write=2s"}
+	var got map[string]time.Duration
+	og := opts.NewGroup("test-parsing")
+	og.DurationMapZero(&got, "timeout", opts.Split(","))
+
+	if err := og.Parse(args); err != nil {
+		t.Fatalf("og.Parse(%v) returns err == %v; want nil", args, err)
+	}
+
+	want := map[string]time.Duration{"read": time.Second, "write": 2 * time.Second}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("og.Parse(%v); (-want +got):\n%s", args, diff)
+	}
+}
+
+func TestParseMapErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args    []string
+		wantKey string
+	}{
+		"bad value names key": {
+			args:    []string{"--limit", "cpu=lots"},
+			wantKey: "cpu",
+		},
+		"missing equals": {
+			args:    []string{"--limit", "cpu"},
+			wantKey: "",
+		},
+		"missing key": {
+			args:    []string{"--limit", "=4"},
+			wantKey: "",
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var got map[string]uint
+			og := opts.NewGroup("test-parsing")
+			og.UintMapZero(&got, "limit")
+
+			err := og.Parse(tc.args)
+			var ive *opts.InvalidValueError
+			if !errors.As(err, &ive) {
+				t.Fatalf("og.Parse(%v) returns %T; want InvalidValueError", tc.args, err)
+			}
+
+			if ive.Key != tc.wantKey {
+				t.Errorf("og.Parse(%v) reports key %q; want %q", tc.args, ive.Key, tc.wantKey)
+			}
+		})
+	}
+}
+
+func TestDuplicateKeysRequiresMap(t *testing.T) {
+	t.Parallel()
+
+	og := opts.NewGroup("test-parsing")
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic when DuplicateKeys is used with a non-map option")
+		}
+	}()
+	var s []string
+	og.StringSliceZero(&s, "name", opts.DuplicateKeys(opts.KeepFirst))
+}
@@ -0,0 +1,242 @@
+package opts_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func TestParseNegatableBool(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args     []string
+		defValue bool
+		want     bool
+	}{
+		"default true": {
+			args:     []string{},
+			defValue: true,
+			want:     true,
+		},
+		"negated": {
+			args:     []string{"--no-color"},
+			defValue: true,
+			want:     false,
+		},
+		"negated with one dash": {
+			args:     []string{"-no-color"},
+			defValue: true,
+			want:     false,
+		},
+		"switched on": {
+			args:     []string{"--color"},
+			defValue: false,
+			want:     true,
+		},
+		"last one wins": {
+			args:     []string{"--no-color", "--color"},
+			defValue: false,
+			want:     true,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var got bool
+			og := opts.NewGroup("test-parsing")
+			og.NegatableBool(&got, "color", tc.defValue)
+
+			if err := og.Parse(tc.args); err != nil {
+				t.Fatalf("og.Parse(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if got != tc.want {
+				t.Errorf("og.Parse(%v) assigns %t to got; want %t", tc.args, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseNegatableBoolConfig(t *testing.T) {
+	t.Parallel()
+
+	var got bool
+	og := opts.NewGroup("test-parsing")
+	og.NegatableBool(&got, "color", true)
+
+	config := "no-color\n"
+	if err := og.ReadConfig(strings.NewReader(config), "test.ini"); err != nil {
+		t.Fatalf("og.ReadConfig(%q) returns err == %v; want nil", config, err)
+	}
+	if got {
+		t.Fatalf("og.ReadConfig(%q) assigns true to got; want false", config)
+	}
+
+	args := []string{"--color"}
+	if err := og.Parse(args); err != nil {
+		t.Fatalf("og.Parse(%v) returns err == %v; want nil", args, err)
+	}
+	if !got {
+		t.Errorf("og.Parse(%v) assigns false to got; want true", args)
+	}
+}
+
+func TestParseNegatableBoolErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		errWanted error
+		args      []string
+	}{
+		"negation with value": {
+			args:      []string{"--no-color=true"},
+			errWanted: opts.ErrBooleanWithValue,
+		},
+		"negation of plain bool": {
+			args:      []string{"--no-verbose"},
+			errWanted: opts.ErrUnknownOption,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var color, verbose bool
+			og := opts.NewGroup("test-parsing")
+			og.NegatableBool(&color, "color", true)
+			og.Bool(&verbose, "verbose")
+
+			err := og.Parse(tc.args)
+			if !errors.Is(err, tc.errWanted) {
+				t.Errorf("og.Parse(%v) returns err == %v; want %v", tc.args, err, tc.errWanted)
+			}
+		})
+	}
+}
+
+func TestNegatableBoolCollision(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		define func(*opts.Group)
+	}{
+		"negation defined first": {
+			define: func(og *opts.Group) {
+				var a, b bool
+				og.Bool(&a, "no-color")
+				og.NegatableBool(&b, "color", true)
+			},
+		},
+		"negation defined second": {
+			define: func(og *opts.Group) {
+				var a, b bool
+				og.NegatableBool(&b, "color", true)
+				og.Bool(&a, "no-color")
+			},
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			og := opts.NewGroup("test-parsing")
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("expected panic on colliding negation")
+				}
+			}()
+			tc.define(og)
+		})
+	}
+}
+
+func TestParseBoolValues(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args     []string
+		postArgs []string
+		want     bool
+	}{
+		"explicit true": {
+			args:     []string{"--write=true"},
+			postArgs: []string{},
+			want:     true,
+		},
+		"explicit false": {
+			args:     []string{"--write=true", "--write=false"},
+			postArgs: []string{},
+			want:     false,
+		},
+		"spaced value is not consumed": {
+			args:     []string{"--write", "false"},
+			postArgs: []string{"false"},
+			want:     true,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var got bool
+			og := opts.NewGroup("test-parsing")
+			og.SetBoolValues(true)
+			og.Bool(&got, "write")
+
+			remaining, err := og.ParseKnown(tc.args)
+			if err != nil {
+				t.Fatalf("og.ParseKnown(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if got != tc.want {
+				t.Errorf("og.ParseKnown(%v) assigns %t to got; want %t", tc.args, got, tc.want)
+			}
+
+			if diff := cmp.Diff(tc.postArgs, remaining); diff != "" {
+				t.Errorf("og.ParseKnown(%v); (-want +got):\n%s", tc.args, diff)
+			}
+		})
+	}
+}
+
+func TestParseBoolValuesInvalid(t *testing.T) {
+	t.Parallel()
+
+	args := []string{"--write=yes"}
+	var got bool
+	og := opts.NewGroup("test-parsing")
+	og.SetBoolValues(true)
+	og.Bool(&got, "write")
+
+	err := og.Parse(args)
+	var ive *opts.InvalidValueError
+	if !errors.As(err, &ive) {
+		t.Errorf("og.Parse(%v) returns %T; want InvalidValueError", args, err)
+	}
+}
+
+func TestNegatableBoolUsage(t *testing.T) {
+	t.Parallel()
+
+	var color bool
+	og := opts.NewGroup("test-usage")
+	og.NegatableBool(&color, "color", true, opts.Help("colorize output"))
+
+	want := "Usage: test-usage [options]\n\nOptions:\n" +
+		"  --[no-]color  colorize output (default true)\n"
+
+	var b strings.Builder
+	og.Usage(&b)
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("og.Usage(); (-want +got):\n%s", diff)
+	}
+}
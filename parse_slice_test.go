@@ -0,0 +1,189 @@
+package opts_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func TestParseStringSlice(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args []string
+		want []string
+	}{
+		"no occurrences keeps default": {
+			args: []string{},
+			want: []string{"default"},
+		},
+		"one occurrence replaces default": {
+			args: []string{"--include", "a"},
+			want: []string{"a"},
+		},
+		"repeated occurrences append": {
+			args: []string{"--include", "a", "-include=b", "--include", "c"},
+			want: []string{"a", "b", "c"},
+		},
+		"commas are not split by default": {
+			args: []string{"--include", "a,b"},
+			want: []string{"a,b"},
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			og := opts.NewGroup("test-parsing")
+			og.StringSlice(&got, "include", []string{"default"})
+
+			if err := og.Parse(tc.args); err != nil {
+				t.Fatalf("og.Parse(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("og.Parse(%v); (-want +got):\n%s", tc.args, diff)
+			}
+		})
+	}
+}
+
+func TestParseSliceSplit(t *testing.T) {
+	t.Parallel()
+
+	args := []string{"--n", "1,2", "--n=3"}
+	var got []int
+	og := opts.NewGroup("test-parsing")
+	og.IntSliceZero(&got, "n", opts.Split(","))
+
+	if err := og.Parse(args); err != nil {
+		t.Fatalf("og.Parse(%v) returns err == %v; want nil", args, err)
+	}
+
+	if diff := cmp.Diff([]int{1, 2, 3}, got); diff != "" {
+		t.Errorf("og.Parse(%v); (-want +got):\n%s", args, diff)
+	}
+}
+
+func TestParseSliceTypes(t *testing.T) {
+	t.Parallel()
+
+	var (
+		uints     []uint
+		floats    []float64
+		durations []time.Duration
+		dates     []civil.Date
+	)
+	og := opts.NewGroup("test-parsing")
+	og.UintSliceZero(&uints, "u")
+	og.Float64SliceZero(&floats, "f")
+	og.DurationSlice(&durations, "d", []time.Duration{time.Hour})
+	og.DateSliceZero(&dates, "date", opts.Split(","))
+
+	args := []string{"-u", "1", "-u", "2", "-f", "0.5", "-d", "1s", "-d", "2m", "-date", "2025-01-02,2025-03-04"}
+	if err := og.Parse(args); err != nil {
+		t.Fatalf("og.Parse(%v) returns err == %v; want nil", args, err)
+	}
+
+	if diff := cmp.Diff([]uint{1, 2}, uints); diff != "" {
+		t.Errorf("og.Parse(%v) uints; (-want +got):\n%s", args, diff)
+	}
+	if diff := cmp.Diff([]float64{0.5}, floats); diff != "" {
+		t.Errorf("og.Parse(%v) floats; (-want +got):\n%s", args, diff)
+	}
+	if diff := cmp.Diff([]time.Duration{time.Second, 2 * time.Minute}, durations); diff != "" {
+		t.Errorf("og.Parse(%v) durations; (-want +got):\n%s", args, diff)
+	}
+	wantDates := []civil.Date{{Year: 2025, Month: 1, Day: 2}, {Year: 2025, Month: 3, Day: 4}}
+	if diff := cmp.Diff(wantDates, dates); diff != "" {
+		t.Errorf("og.Parse(%v) dates; (-want +got):\n%s", args, diff)
+	}
+}
+
+func TestParseSliceDefaultUnchanged(t *testing.T) {
+	t.Parallel()
+
+	defValue := []string{"x", "y"}
+	var got []string
+	og := opts.NewGroup("test-parsing")
+	og.StringSlice(&got, "s", defValue[:1])
+
+	args := []string{"-s", "a", "-s", "b"}
+	if err := og.Parse(args); err != nil {
+		t.Fatalf("og.Parse(%v) returns err == %v; want nil", args, err)
+	}
+
+	if diff := cmp.Diff([]string{"x", "y"}, defValue); diff != "" {
+		t.Errorf("og.Parse(%v) modifies default; (-want +got):\n%s", args, diff)
+	}
+}
+
+func TestParseSliceConfigThenCommandLine(t *testing.T) {
+	t.Parallel()
+
+	var got []string
+	og := opts.NewGroup("test-parsing")
+	og.StringSliceZero(&got, "include")
+
+	config := "include = a\ninclude = b\n"
+	if err := og.ReadConfig(strings.NewReader(config), "test.ini"); err != nil {
+		t.Fatalf("og.ReadConfig(%q) returns err == %v; want nil", config, err)
+	}
+
+	args := []string{"--include", "c"}
+	if err := og.Parse(args); err != nil {
+		t.Fatalf("og.Parse(%v) returns err == %v; want nil", args, err)
+	}
+
+	if diff := cmp.Diff([]string{"c"}, got); diff != "" {
+		t.Errorf("og.Parse(%v); (-want +got):\n%s", args, diff)
+	}
+}
+
+func TestParseSliceErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args []string
+	}{
+		"bad value":            {args: []string{"-n", "x"}},
+		"bad value after good": {args: []string{"-n", "1", "-n", "x"}},
+		"bad split value":      {args: []string{"-n", "1,x"}},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var got []int
+			og := opts.NewGroup("test-parsing")
+			og.IntSliceZero(&got, "n", opts.Split(","))
+
+			err := og.Parse(tc.args)
+			var ive *opts.InvalidValueError
+			if !errors.As(err, &ive) {
+				t.Fatalf("og.Parse(%v) returns %T; want InvalidValueError", tc.args, err)
+			}
+		})
+	}
+}
+
+func TestSplitRequiresRepeatable(t *testing.T) {
+	t.Parallel()
+
+	og := opts.NewGroup("test-parsing")
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic when Split is used with a non-repeatable option")
+		}
+	}()
+	var s string
+	og.StringZero(&s, "name", opts.Split(","))
+}
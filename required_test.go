@@ -0,0 +1,178 @@
+package opts_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func TestRequiredSatisfied(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args []string
+	}{
+		"both given":         {args: []string{"--rcfile", "x.ini", "--level", "0"}},
+		"given with equals":  {args: []string{"--level=2", "--rcfile=x.ini"}},
+		"same as default ok": {args: []string{"--rcfile", "", "--level", "0", "--name", "default"}},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				rcfile string
+				level  uint
+				name   string
+			)
+			og := opts.NewGroup("test-required")
+			og.StringZero(&rcfile, "rcfile", opts.Required())
+			og.UintZero(&level, "level", opts.Required())
+			og.String(&name, "name", "default")
+
+			if err := og.Parse(tc.args); err != nil {
+				t.Errorf("og.Parse(%v) returns err == %v; want nil", tc.args, err)
+			}
+		})
+	}
+}
+
+func TestRequiredMissing(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args []string
+		want []string
+	}{
+		"none given": {
+			args: []string{},
+			want: []string{"level", "rcfile"},
+		},
+		"one given": {
+			args: []string{"--level", "3"},
+			want: []string{"rcfile"},
+		},
+		"optional given": {
+			args: []string{"--name", "x"},
+			want: []string{"level", "rcfile"},
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				rcfile string
+				level  uint
+				name   string
+			)
+			og := opts.NewGroup("test-required")
+			og.StringZero(&rcfile, "rcfile", opts.Required())
+			og.UintZero(&level, "level", opts.Required())
+			og.String(&name, "name", "default")
+
+			err := og.Parse(tc.args)
+
+			var moe *opts.MissingOptionsError
+			if !errors.As(err, &moe) {
+				t.Fatalf("og.Parse(%v) returns %T; want MissingOptionsError", tc.args, err)
+			}
+
+			if diff := cmp.Diff(tc.want, moe.Options); diff != "" {
+				t.Errorf("og.Parse(%v); (-want +got):\n%s", tc.args, diff)
+			}
+
+			og2 := opts.NewGroup("test-required")
+			og2.StringZero(&rcfile, "rcfile", opts.Required())
+			og2.UintZero(&level, "level", opts.Required())
+			og2.String(&name, "name", "default")
+
+			if _, err := og2.ParseKnown(tc.args); !errors.As(err, &moe) {
+				t.Errorf("og.ParseKnown(%v) returns %T; want MissingOptionsError", tc.args, err)
+			}
+		})
+	}
+}
+
+func TestRequiredFromConfig(t *testing.T) {
+	t.Parallel()
+
+	var (
+		rcfile string
+		level  uint
+		name   string
+	)
+	og := opts.NewGroup("test-required")
+	og.StringZero(&rcfile, "rcfile", opts.Required())
+	og.UintZero(&level, "level", opts.Required())
+	og.String(&name, "name", "default")
+
+	config := "rcfile = x.ini\nlevel = 1\n"
+	if err := og.ReadConfig(strings.NewReader(config), "test.ini"); err != nil {
+		t.Fatalf("og.ReadConfig(%q) returns err == %v; want nil", config, err)
+	}
+
+	if err := og.Parse([]string{}); err != nil {
+		t.Errorf("og.Parse([]) after config returns err == %v; want nil", err)
+	}
+}
+
+func TestMissingOptionsErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		options []string
+		want    string
+	}{
+		"one":  {options: []string{"rcfile"}, want: "opts: missing required option: --rcfile"},
+		"many": {options: []string{"a", "b"}, want: "opts: missing required options: --a, --b"},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			err := &opts.MissingOptionsError{Options: tc.options}
+			if err.Error() != tc.want {
+				t.Errorf("err.Error() == %q; want %q", err.Error(), tc.want)
+			}
+		})
+	}
+}
+
+func TestRequiredRetry(t *testing.T) {
+	t.Parallel()
+
+	var (
+		rcfile string
+		n      int
+	)
+	og := opts.NewGroup("test-required")
+	og.StringZero(&rcfile, "rcfile", opts.Required())
+	og.IntZero(&n, "n")
+
+	args := []string{"--rcfile", "a", "--n", "zz"}
+	var ive *opts.InvalidValueError
+	if err := og.Parse(args); !errors.As(err, &ive) {
+		t.Fatalf("og.Parse(%v) returns err == %v; want InvalidValueError", args, err)
+	}
+
+	args = []string{"--n", "3"}
+	var moe *opts.MissingOptionsError
+	if err := og.Parse(args); !errors.As(err, &moe) {
+		t.Fatalf("og.Parse(%v) after failure returns err == %v; want MissingOptionsError", args, err)
+	}
+
+	if diff := cmp.Diff([]string{"rcfile"}, moe.Options); diff != "" {
+		t.Errorf("og.Parse(%v) after failure; (-want +got):\n%s", args, diff)
+	}
+
+	if rcfile != "" {
+		t.Errorf("og.Parse(%v) after failure leaves rcfile = %q; want %q", args, rcfile, "")
+	}
+}
@@ -0,0 +1,143 @@
+package opts
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// maxResponseDepth limits how deeply response files may refer to other
+// response files. The limit also stops a file that refers to itself.
+const maxResponseDepth = 10
+
+// SetResponseFiles turns response file expansion on or off. It is off by
+// default.
+//
+// When expansion is on, the parsing methods replace any argument of the form
+// "@path" with the contents of the file at path, split into arguments before
+// parsing begins. Arguments in the file are separated by whitespace. Single
+// quotes preserve everything between them; double quotes preserve everything
+// except that a backslash escapes the next character; outside of quotes,
+// a backslash also escapes the next character. Response files may refer to
+// other response files, up to a depth of ten.
+//
+// An argument that begins with "@@" stands for a literal argument that begins
+// with a single "@", and an argument of just "@" is left alone. Nothing after
+// "--" is expanded. Note that expansion happens before parsing, so the value
+// of an option, as in "--name @x", is expanded too unless it is escaped.
+//
+// If a file cannot be read, is nested too deeply, or is malformed, the parsing
+// methods return a [*ResponseFileError] that names the file.
+func (g *Group) SetResponseFiles(on bool) {
+	g.responseFiles = on
+}
+
+// An expander collects the arguments that result from expanding response
+// files.
+type expander struct {
+	args  []string
+	ended bool
+}
+
+func (e *expander) expand(args []string, depth int) error {
+	for _, arg := range args {
+		switch {
+		case e.ended || arg == "@" || !strings.HasPrefix(arg, "@"):
+			e.args = append(e.args, arg)
+			e.ended = e.ended || arg == "--"
+		case strings.HasPrefix(arg, "@@"):
+			e.args = append(e.args, arg[1:])
+		default:
+			if err := e.expandFile(arg[1:], depth); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (e *expander) expandFile(file string, depth int) error {
+	if depth >= maxResponseDepth {
+		return &ResponseFileError{File: file, Err: ErrResponseFileDepth}
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return &ResponseFileError{File: file, Err: err}
+	}
+
+	words, err := splitResponse(string(data))
+	if err != nil {
+		return &ResponseFileError{File: file, Err: err}
+	}
+
+	return e.expand(words, depth+1)
+}
+
+// expandResponseFiles returns args with every response file expanded.
+func expandResponseFiles(args []string) ([]string, error) {
+	e := &expander{args: make([]string, 0, len(args))}
+	if err := e.expand(args, 0); err != nil {
+		return nil, err
+	}
+
+	return e.args, nil
+}
+
+// splitResponse splits the contents of a response file into arguments.
+func splitResponse(s string) ([]string, error) {
+	var sp splitter
+	for _, r := range s {
+		sp.add(r)
+	}
+
+	if sp.quote != 0 || sp.escape {
+		return nil, errors.New("unterminated quote or escape")
+	}
+
+	sp.endWord()
+
+	return sp.words, nil
+}
+
+// A splitter accumulates arguments one rune at a time.
+type splitter struct {
+	words  []string
+	word   strings.Builder
+	quote  rune
+	inWord bool
+	escape bool
+}
+
+func (sp *splitter) add(r rune) {
+	switch {
+	case sp.escape:
+		sp.word.WriteRune(r)
+		sp.escape = false
+	case r == '\\' && sp.quote != '\'':
+		sp.escape, sp.inWord = true, true
+	case sp.quote != 0 && r == sp.quote:
+		sp.quote = 0
+	case sp.quote != 0:
+		sp.word.WriteRune(r)
+	case r == '\'' || r == '"':
+		sp.quote, sp.inWord = r, true
+	case unicode.IsSpace(r):
+		sp.endWord()
+	default:
+		sp.word.WriteRune(r)
+		sp.inWord = true
+	}
+}
+
+func (sp *splitter) endWord() {
+	if !sp.inWord {
+		return
+	}
+
+	sp.words = append(sp.words, sp.word.String())
+	sp.word.Reset()
+	sp.inWord = false
+}
@@ -0,0 +1,176 @@
+package opts_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func writeResponseFile(t *testing.T, dir, name, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestParseResponseFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	simple := writeResponseFile(t, dir, "simple.rsp", "--name alpha\n--count 3 extra\n")
+	quoted := writeResponseFile(t, dir, "quoted.rsp", `--name "two words" 'it''s' a\ b "q\"q" ''`)
+	inner := writeResponseFile(t, dir, "inner.rsp", "--count 7")
+	outer := writeResponseFile(t, dir, "outer.rsp", "--name outer '@"+inner+"'")
+
+	testCases := map[string]struct {
+		args      []string
+		postArgs  []string
+		wantName  string
+		wantCount int
+	}{
+		"simple file": {
+			args:      []string{"@" + simple},
+			postArgs:  []string{"extra"},
+			wantName:  "alpha",
+			wantCount: 3,
+		},
+		"quoting and escapes": {
+			args:     []string{"@" + quoted},
+			postArgs: []string{"its", "a b", `q"q`, ""},
+			wantName: "two words",
+		},
+		"nested files": {
+			args:      []string{"@" + outer},
+			postArgs:  []string{},
+			wantName:  "outer",
+			wantCount: 7,
+		},
+		"parsing stops at positional from file": {
+			args:      []string{"@" + simple, "--name", "beta"},
+			postArgs:  []string{"extra", "--name", "beta"},
+			wantName:  "alpha",
+			wantCount: 3,
+		},
+		"escaped at sign": {
+			args:     []string{"--name", "@@handle"},
+			postArgs: []string{},
+			wantName: "@handle",
+		},
+		"lone at sign": {
+			args:     []string{"@"},
+			postArgs: []string{"@"},
+		},
+		"nothing expanded after double dash": {
+			args:     []string{"--", "@" + simple},
+			postArgs: []string{"@" + simple},
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				name  string
+				count int
+			)
+			og := opts.NewGroup("test-parsing")
+			og.SetResponseFiles(true)
+			og.StringZero(&name, "name")
+			og.IntZero(&count, "count")
+
+			remaining, err := og.ParseKnown(tc.args)
+			if err != nil {
+				t.Fatalf("og.ParseKnown(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if name != tc.wantName {
+				t.Errorf("og.ParseKnown(%v) assigns %q to name; want %q", tc.args, name, tc.wantName)
+			}
+			if count != tc.wantCount {
+				t.Errorf("og.ParseKnown(%v) assigns %d to count; want %d", tc.args, count, tc.wantCount)
+			}
+			if diff := cmp.Diff(tc.postArgs, remaining); diff != "" {
+				t.Errorf("og.ParseKnown(%v); (-want +got):\n%s", tc.args, diff)
+			}
+		})
+	}
+}
+
+func TestParseResponseFilesOff(t *testing.T) {
+	t.Parallel()
+
+	args := []string{"@file"}
+	og := opts.NewGroup("test-parsing")
+
+	remaining, err := og.ParseKnown(args)
+	if err != nil {
+		t.Fatalf("og.ParseKnown(%v) returns err == %v; want nil", args, err)
+	}
+
+	if diff := cmp.Diff(args, remaining); diff != "" {
+		t.Errorf("og.ParseKnown(%v); (-want +got):\n%s", args, diff)
+	}
+}
+
+func TestParseResponseFileErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing.rsp")
+	unterminated := writeResponseFile(t, dir, "unterminated.rsp", `--name "oops`)
+	loop := filepath.Join(dir, "loop.rsp")
+	writeResponseFile(t, dir, "loop.rsp", "'@"+loop+"'")
+
+	testCases := map[string]struct {
+		errWanted error
+		file      string
+	}{
+		"missing file": {
+			file:      missing,
+			errWanted: os.ErrNotExist,
+		},
+		"unterminated quote": {
+			file: unterminated,
+		},
+		"self reference": {
+			file:      loop,
+			errWanted: opts.ErrResponseFileDepth,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var name string
+			og := opts.NewGroup("test-parsing")
+			og.SetResponseFiles(true)
+			og.StringZero(&name, "name")
+
+			args := []string{"@" + tc.file}
+			err := og.Parse(args)
+
+			var rfe *opts.ResponseFileError
+			if !errors.As(err, &rfe) {
+				t.Fatalf("og.Parse(%v) returns %T; want ResponseFileError", args, err)
+			}
+
+			if rfe.File != tc.file {
+				t.Errorf("og.Parse(%v) reports file %q; want %q", args, rfe.File, tc.file)
+			}
+
+			if tc.errWanted != nil && !errors.Is(err, tc.errWanted) {
+				t.Errorf("og.Parse(%v) returns err == %v; want %v", args, err, tc.errWanted)
+			}
+		})
+	}
+}
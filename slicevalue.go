@@ -0,0 +1,135 @@
+package opts
+
+import (
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// A sliceValue collects every value given for a repeatable option.
+type sliceValue[T any] struct {
+	ptr     *[]T
+	convert func(string) (T, error)
+}
+
+func (v *sliceValue[T]) set(s string) error {
+	val, err := v.convert(s)
+	if err != nil {
+		return err
+	}
+
+	*v.ptr = append(*v.ptr, val)
+
+	return nil
+}
+
+// reset discards the current values so that the first value from a new
+// source replaces, rather than extends, the values from earlier sources.
+func (v *sliceValue[T]) reset() {
+	*v.ptr = nil
+}
+
+// save keeps the slice itself, since set only appends and reset replaces the
+// slice rather than changing its elements.
+func (v *sliceValue[T]) save() func() {
+	saved := *v.ptr
+	return func() { *v.ptr = saved }
+}
+
+func (v *sliceValue[T]) String() string {
+	return fmt.Sprint(*v.ptr)
+}
+
+func (v *sliceValue[T]) isZero() bool {
+	return len(*v.ptr) == 0
+}
+
+// defineSlice defines a repeatable option for the caller named funcName.
+func defineSlice[T any](g *Group, funcName string, p *[]T, name string, defValue []T, convert func(string) (T, error), typeName string, mods []Modifier) {
+	if err := validateName(funcName, name); err != nil {
+		panic(err)
+	}
+
+	*p = defValue
+	opt := &opt{
+		value: &sliceValue[T]{
+			ptr:     p,
+			convert: convert,
+		},
+		name:       name,
+		typeName:   typeName,
+		isBool:     false,
+		repeatable: true,
+	}
+
+	g.addOpt(funcName, opt, mods)
+}
+
+// StringSlice defines a repeatable string option with the specified name and
+// default value. The argument s points to a []string variable that will store
+// every value given for the option, in order. The first value from the command
+// line (or from any other source) replaces the default rather than adding to
+// it. Use the [Split] modifier to also accept several values in one argument,
+// e.g., "--include a,b". StringSlice will panic if name is not valid or
+// repeats an existing option.
+func (g *Group) StringSlice(s *[]string, name string, defValue []string, mods ...Modifier) {
+	defineSlice(g, "StringSlice", s, name, defValue, toString, "string", mods)
+}
+
+// StringSliceZero is like StringSlice but with a default value of nil.
+func (g *Group) StringSliceZero(s *[]string, name string, mods ...Modifier) {
+	g.StringSlice(s, name, nil, mods...)
+}
+
+// IntSlice is like [*Group.StringSlice] but collects int values.
+func (g *Group) IntSlice(i *[]int, name string, defValue []int, mods ...Modifier) {
+	defineSlice(g, "IntSlice", i, name, defValue, toInt, "int", mods)
+}
+
+// IntSliceZero is like IntSlice but with a default value of nil.
+func (g *Group) IntSliceZero(i *[]int, name string, mods ...Modifier) {
+	g.IntSlice(i, name, nil, mods...)
+}
+
+// UintSlice is like [*Group.StringSlice] but collects uint values.
+func (g *Group) UintSlice(u *[]uint, name string, defValue []uint, mods ...Modifier) {
+	defineSlice(g, "UintSlice", u, name, defValue, toUint, "uint", mods)
+}
+
+// UintSliceZero is like UintSlice but with a default value of nil.
+func (g *Group) UintSliceZero(u *[]uint, name string, mods ...Modifier) {
+	g.UintSlice(u, name, nil, mods...)
+}
+
+// Float64Slice is like [*Group.StringSlice] but collects float64 values.
+func (g *Group) Float64Slice(f *[]float64, name string, defValue []float64, mods ...Modifier) {
+	defineSlice(g, "Float64Slice", f, name, defValue, toFloat64, "float64", mods)
+}
+
+// Float64SliceZero is like Float64Slice but with a default value of nil.
+func (g *Group) Float64SliceZero(f *[]float64, name string, mods ...Modifier) {
+	g.Float64Slice(f, name, nil, mods...)
+}
+
+// DurationSlice is like [*Group.StringSlice] but collects time.Duration values.
+// See [*Group.Duration] for the format of each value.
+func (g *Group) DurationSlice(d *[]time.Duration, name string, defValue []time.Duration, mods ...Modifier) {
+	defineSlice(g, "DurationSlice", d, name, defValue, time.ParseDuration, "duration", mods)
+}
+
+// DurationSliceZero is like DurationSlice but with a default value of nil.
+func (g *Group) DurationSliceZero(d *[]time.Duration, name string, mods ...Modifier) {
+	g.DurationSlice(d, name, nil, mods...)
+}
+
+// DateSlice is like [*Group.StringSlice] but collects civil.Date values. See
+// [*Group.Date] for the format of each value.
+func (g *Group) DateSlice(d *[]civil.Date, name string, defValue []civil.Date, mods ...Modifier) {
+	defineSlice(g, "DateSlice", d, name, defValue, civil.ParseDate, "date", mods)
+}
+
+// DateSliceZero is like DateSlice but with a default value of nil.
+func (g *Group) DateSliceZero(d *[]civil.Date, name string, mods ...Modifier) {
+	g.DateSlice(d, name, nil, mods...)
+}
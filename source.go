@@ -0,0 +1,65 @@
+package opts
+
+import "iter"
+
+// A Source records where an option's value came from. Sources are ordered by
+// precedence: a value from a later source is never replaced by a value from
+// an earlier one.
+type Source int
+
+// The sources of an option's value, from lowest to highest precedence.
+const (
+	SourceDefault Source = iota
+	SourceConfig
+	SourceEnv
+	SourceCommandLine
+)
+
+func (s Source) String() string {
+	switch s {
+	case SourceDefault:
+		return "default"
+	case SourceConfig:
+		return "config"
+	case SourceEnv:
+		return "env"
+	case SourceCommandLine:
+		return "command line"
+	default:
+		return "unknown"
+	}
+}
+
+// IsSet reports whether the named option received a value from the command
+// line, the environment, or a configuration file. IsSet returns false for an
+// option that kept its default and for a name that is not defined.
+func (g *Group) IsSet(name string) bool {
+	src, ok := g.Source(name)
+
+	return ok && src != SourceDefault
+}
+
+// Source returns the source of the named option's current value. The boolean
+// result reports whether name is defined in the Group. A value given on the
+// command line that happens to equal the default still reports
+// [SourceCommandLine].
+func (g *Group) Source(name string) (Source, bool) {
+	o, ok := g.opts[name]
+	if !ok {
+		return SourceDefault, false
+	}
+
+	return o.source, true
+}
+
+// Sources returns an iterator over the name and source of every option in the
+// Group, sorted by name.
+func (g *Group) Sources() iter.Seq2[string, Source] {
+	return func(yield func(string, Source) bool) {
+		for _, name := range g.sortedNames() {
+			if !yield(name, g.opts[name].source) {
+				return
+			}
+		}
+	}
+}
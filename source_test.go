@@ -0,0 +1,122 @@
+package opts_test
+
+import (
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func TestSource(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		config string
+		args   []string
+		want   map[string]opts.Source
+	}{
+		"all defaults": {
+			args: []string{},
+			want: map[string]opts.Source{
+				"color":      opts.SourceDefault,
+				"convention": opts.SourceDefault,
+				"strictness": opts.SourceDefault,
+				"verbose":    opts.SourceDefault,
+			},
+		},
+		"default value given on command line": {
+			args: []string{"--strictness", "3", "-verbose"},
+			want: map[string]opts.Source{
+				"color":      opts.SourceDefault,
+				"convention": opts.SourceDefault,
+				"strictness": opts.SourceCommandLine,
+				"verbose":    opts.SourceCommandLine,
+			},
+		},
+		"config and command line": {
+			config: "convention = snake\nstrictness = 5\n",
+			args:   []string{"--strictness", "4", "--no-color"},
+			want: map[string]opts.Source{
+				"color":      opts.SourceCommandLine,
+				"convention": opts.SourceConfig,
+				"strictness": opts.SourceCommandLine,
+				"verbose":    opts.SourceDefault,
+			},
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				strictness uint
+				convention string
+				verbosity  int
+				color      bool
+			)
+			og := opts.NewGroup("test-source")
+			og.Uint(&strictness, "strictness", 3)
+			og.String(&convention, "convention", "camel")
+			og.Counter(&verbosity, "verbose")
+			og.NegatableBool(&color, "color", true)
+
+			if err := og.Parse(tc.args); err != nil {
+				t.Fatalf("og.Parse(%v) returns err == %v; want nil", tc.args, err)
+			}
+			if err := og.ReadConfig(strings.NewReader(tc.config), "test.ini"); err != nil {
+				t.Fatalf("og.ReadConfig returns err == %v; want nil", err)
+			}
+
+			got := maps.Collect(og.Sources())
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("og.Sources(); (-want +got):\n%s", diff)
+			}
+
+			for name, want := range tc.want {
+				src, ok := og.Source(name)
+				if !ok || src != want {
+					t.Errorf("og.Source(%q) = %v, %t; want %v, true", name, src, ok, want)
+				}
+				if got, want := og.IsSet(name), want != opts.SourceDefault; got != want {
+					t.Errorf("og.IsSet(%q) = %t; want %t", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSourceUnknown(t *testing.T) {
+	t.Parallel()
+
+	var color bool
+	og := opts.NewGroup("test-source")
+	og.NegatableBool(&color, "color", true)
+
+	if src, ok := og.Source("no-color"); ok {
+		t.Errorf("og.Source(%q) = %v, true; want false", "no-color", src)
+	}
+
+	if og.IsSet("quiet") {
+		t.Errorf("og.IsSet(%q) = true; want false", "quiet")
+	}
+}
+
+// This test cannot run in parallel because it calls t.Setenv.
+func TestSourceEnv(t *testing.T) {
+	t.Setenv("OPTS_TEST_CONVENTION", "kebab")
+
+	var convention string
+	og := opts.NewGroup("test-source")
+	og.String(&convention, "convention", "camel", opts.Env("OPTS_TEST_CONVENTION"))
+
+	if err := og.Parse([]string{}); err != nil {
+		t.Fatalf("og.Parse returns err == %v; want nil", err)
+	}
+
+	if src, _ := og.Source("convention"); src != opts.SourceEnv {
+		t.Errorf("og.Source(%q) = %v; want %v", "convention", src, opts.SourceEnv)
+	}
+}
@@ -4,7 +4,7 @@ package opts
 // The argument s points to a string variable that will store the value of the
 // option. String will panic if name is not valid or repeats an existing
 // option.
-func (g *Group) String(s *string, name, defValue string) {
+func (g *Group) String(s *string, name, defValue string, mods ...Modifier) {
 	if err := validateName("String", name); err != nil {
 		panic(err)
 	}
@@ -15,19 +15,17 @@ func (g *Group) String(s *string, name, defValue string) {
 			ptr:     s,
 			convert: toString,
 		},
-		name:   name,
-		isBool: false,
+		name:     name,
+		typeName: "string",
+		isBool:   false,
 	}
 
-	if err := g.optAlreadySet(name); err != nil {
-		panic(err)
-	}
-	g.opts[name] = opt
+	g.addOpt("String", opt, mods)
 }
 
 // StringZero is like String but with a default value of "".
-func (g *Group) StringZero(s *string, name string) {
-	g.String(s, name, "")
+func (g *Group) StringZero(s *string, name string, mods ...Modifier) {
+	g.String(s, name, "", mods...)
 }
 
 func toString(s string) (string, error) {
@@ -0,0 +1,70 @@
+package opts
+
+import (
+	"cmp"
+	"slices"
+)
+
+// unknownOption returns an UnknownOptionError for name that suggests the
+// registered names, including aliases and "no-" forms of negatable booleans,
+// that are closest to name. A name is suggested only if it is within a third
+// of name's length in edits, so short typos yield no guesses.
+func (g *Group) unknownOption(name string) *UnknownOptionError {
+	type suggestion struct {
+		name string
+		dist int
+	}
+
+	limit := len([]rune(name)) / 3
+	var found []suggestion
+
+	for _, registered := range g.sortedKeys() {
+		candidates := []string{registered}
+		if g.opts[registered].negatable {
+			candidates = append(candidates, "no-"+registered)
+		}
+
+		for _, c := range candidates {
+			if d := editDistance(name, c); d <= limit {
+				found = append(found, suggestion{c, d})
+			}
+		}
+	}
+
+	slices.SortStableFunc(found, func(a, b suggestion) int {
+		return cmp.Compare(a.dist, b.dist)
+	})
+
+	var suggestions []string
+	for _, s := range found {
+		suggestions = append(suggestions, s.name)
+	}
+
+	return &UnknownOptionError{Name: name, Suggestions: suggestions}
+}
+
+// editDistance returns the Levenshtein distance between a and b, counting
+// runes rather than bytes.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := range ra {
+		curr[0] = i + 1
+		for j := range rb {
+			cost := 1
+			if ra[i] == rb[j] {
+				cost = 0
+			}
+			curr[j+1] = min(prev[j+1]+1, curr[j]+1, prev[j]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
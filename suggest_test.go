@@ -0,0 +1,107 @@
+package opts_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func TestUnknownOptionSuggestions(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args       []string
+		want       []string
+		errMessage string
+	}{
+		"one close name": {
+			args:       []string{"--strictnes", "5"},
+			want:       []string{"strictness"},
+			errMessage: "opts: --strictnes: unknown option; did you mean --strictness?",
+		},
+		"transposed letters": {
+			args:       []string{"--vrebose"},
+			want:       []string{"verbose"},
+			errMessage: "opts: --vrebose: unknown option; did you mean --verbose?",
+		},
+		"nearest first": {
+			args:       []string{"--verbosy"},
+			want:       []string{"verbose", "verbosity"},
+			errMessage: "opts: --verbosy: unknown option; did you mean --verbose or --verbosity?",
+		},
+		"negated name": {
+			args:       []string{"--no-colour"},
+			want:       []string{"no-color"},
+			errMessage: "opts: --no-colour: unknown option; did you mean --no-color?",
+		},
+		"nothing close": {
+			args:       []string{"--quiet"},
+			want:       nil,
+			errMessage: "opts: --quiet: unknown option",
+		},
+		"short name": {
+			args:       []string{"-x"},
+			want:       nil,
+			errMessage: "opts: --x: unknown option",
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				strictness uint
+				verbosity  int
+				verbose    bool
+				color      bool
+			)
+			og := opts.NewGroup("test-suggest")
+			og.UintZero(&strictness, "strictness")
+			og.IntZero(&verbosity, "verbosity")
+			og.Bool(&verbose, "verbose")
+			og.NegatableBool(&color, "color", true)
+
+			err := og.Parse(tc.args)
+			if !errors.Is(err, opts.ErrUnknownOption) {
+				t.Fatalf("og.Parse(%v) returns err == %v; want ErrUnknownOption", tc.args, err)
+			}
+
+			var uoe *opts.UnknownOptionError
+			if !errors.As(err, &uoe) {
+				t.Fatalf("og.Parse(%v) returns %T; want UnknownOptionError", tc.args, err)
+			}
+
+			if diff := cmp.Diff(tc.want, uoe.Suggestions); diff != "" {
+				t.Errorf("og.Parse(%v); (-want +got):\n%s", tc.args, diff)
+			}
+
+			if got := err.Error(); got != tc.errMessage {
+				t.Errorf("err.Error() = %q; want %q", got, tc.errMessage)
+			}
+		})
+	}
+}
+
+func TestUnknownOptionSuggestionsInConfig(t *testing.T) {
+	t.Parallel()
+
+	var strictness uint
+	og := opts.NewGroup("test-suggest")
+	og.UintZero(&strictness, "strictness")
+
+	err := og.ReadConfig(strings.NewReader("strictnes = 5\n"), "test.ini")
+
+	var uoe *opts.UnknownOptionError
+	if !errors.As(err, &uoe) {
+		t.Fatalf("og.ReadConfig returns %T; want UnknownOptionError", err)
+	}
+
+	want := "opts: test.ini:1: --strictnes: unknown option; did you mean --strictness?"
+	if got := err.Error(); got != want {
+		t.Errorf("err.Error() = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,95 @@
+package opts
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+)
+
+// A textVar is a value with both text methods, which lets a textValue
+// render it and restore it after a failed parse.
+type textVar interface {
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+}
+
+// A textValue parses and renders a value through its text methods.
+type textValue struct {
+	ptr textVar
+}
+
+func (v *textValue) set(s string) error {
+	return v.ptr.UnmarshalText([]byte(s))
+}
+
+func (v *textValue) String() string {
+	b, err := v.ptr.MarshalText()
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
+
+// save restores the value by unmarshaling the text it marshals to now.
+func (v *textValue) save() func() {
+	saved, err := v.ptr.MarshalText()
+	if err != nil {
+		return func() {}
+	}
+
+	return func() {
+		if err := v.ptr.UnmarshalText(saved); err != nil {
+			return // Keep whatever the failed parse left.
+		}
+	}
+}
+
+func (v *textValue) isZero() bool {
+	return looksZero(v.String())
+}
+
+// TextVar defines an option with the specified name and default value for
+// a type that implements [encoding.TextUnmarshaler]. The argument p points to
+// a variable that will store the value of the option, such as
+// a [netip.Addr] or a [slog.Level]. Values are parsed with p's UnmarshalText
+// method, and errors from it are reported as an [*InvalidValueError]. p must
+// also implement [encoding.TextMarshaler], which help output uses to render
+// values and which lets a failed parse restore the value.
+//
+// TextVar sets p to defValue by marshaling defValue and unmarshaling the
+// result into p, so defValue is usually the same type as the variable that
+// p points to. TextVar will panic if p is nil or does not implement
+// [encoding.TextMarshaler], if defValue cannot be converted, if name is not
+// valid, or if name repeats an existing option.
+func (g *Group) TextVar(p encoding.TextUnmarshaler, name string, defValue encoding.TextMarshaler, mods ...Modifier) {
+	if err := validateName("TextVar", name); err != nil {
+		panic(err)
+	}
+
+	if p == nil || defValue == nil {
+		panic(errors.New("opts: TextVar: nil value"))
+	}
+
+	tv, ok := p.(textVar)
+	if !ok {
+		panic(fmt.Errorf("opts: TextVar: --%s: %T does not implement encoding.TextMarshaler", name, p))
+	}
+
+	text, err := defValue.MarshalText()
+	if err == nil {
+		err = p.UnmarshalText(text)
+	}
+	if err != nil {
+		panic(fmt.Errorf("opts: TextVar: --%s: invalid default: %w", name, err))
+	}
+
+	opt := &opt{
+		value:    &textValue{ptr: tv},
+		name:     name,
+		typeName: "value",
+		isBool:   false,
+	}
+
+	g.addOpt("TextVar", opt, mods)
+}
@@ -0,0 +1,200 @@
+package opts_test
+
+import (
+	"errors"
+	"log/slog"
+	"math/big"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func TestParseTextVar(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args      []string
+		wantAddr  string
+		wantLevel slog.Level
+		wantCount string
+	}{
+		"defaults": {
+			args:      []string{},
+			wantAddr:  "127.0.0.1",
+			wantLevel: slog.LevelWarn,
+			wantCount: "0",
+		},
+		"values given": {
+			args:      []string{"--addr", "::1", "--level=debug", "--count", "123456789012345678901234567890"},
+			wantAddr:  "::1",
+			wantLevel: slog.LevelDebug,
+			wantCount: "123456789012345678901234567890",
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				addr  netip.Addr
+				level slog.Level
+				count big.Int
+			)
+			og := opts.NewGroup("test-text")
+			og.TextVar(&addr, "addr", netip.MustParseAddr("127.0.0.1"))
+			og.TextVar(&level, "level", slog.LevelWarn)
+			og.TextVar(&count, "count", big.NewInt(0))
+
+			if err := og.Parse(tc.args); err != nil {
+				t.Fatalf("og.Parse(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if got := addr.String(); got != tc.wantAddr {
+				t.Errorf("og.Parse(%v) sets addr to %q; want %q", tc.args, got, tc.wantAddr)
+			}
+
+			if level != tc.wantLevel {
+				t.Errorf("og.Parse(%v) sets level to %v; want %v", tc.args, level, tc.wantLevel)
+			}
+
+			if got := count.String(); got != tc.wantCount {
+				t.Errorf("og.Parse(%v) sets count to %q; want %q", tc.args, got, tc.wantCount)
+			}
+		})
+	}
+}
+
+func TestParseTextVarErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args []string
+		want string
+	}{
+		"bad address": {
+			args: []string{"--addr", "localhost"},
+			want: "addr",
+		},
+		"bad level": {
+			args: []string{"--level", "loud"},
+			want: "level",
+		},
+		"bad number": {
+			args: []string{"--count", "1e3"},
+			want: "count",
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				addr  netip.Addr
+				level slog.Level
+				count big.Int
+			)
+			og := opts.NewGroup("test-text")
+			og.TextVar(&addr, "addr", netip.MustParseAddr("127.0.0.1"))
+			og.TextVar(&level, "level", slog.LevelWarn)
+			og.TextVar(&count, "count", big.NewInt(0))
+
+			err := og.Parse(tc.args)
+
+			var ive *opts.InvalidValueError
+			if !errors.As(err, &ive) {
+				t.Fatalf("og.Parse(%v) returns err == %v; want InvalidValueError", tc.args, err)
+			}
+
+			if ive.Option != tc.want {
+				t.Errorf("og.Parse(%v) returns error for --%s; want --%s", tc.args, ive.Option, tc.want)
+			}
+		})
+	}
+}
+
+func TestTextVarUsage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr  netip.Addr
+		level slog.Level
+		count big.Int
+	)
+	og := opts.NewGroup("test-text")
+	og.TextVar(&addr, "addr", netip.MustParseAddr("127.0.0.1"))
+	og.TextVar(&level, "level", slog.LevelWarn)
+	og.TextVar(&count, "count", big.NewInt(0))
+
+	var b strings.Builder
+	og.Usage(&b)
+
+	want := "Usage: test-text [options]\n\nOptions:\n" +
+		"  --addr value   (default 127.0.0.1)\n" +
+		"  --count value\n" +
+		"  --level value  (default WARN)\n"
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("og.Usage(); (-want +got):\n%s", diff)
+	}
+}
+
+// A word has UnmarshalText but not MarshalText.
+type word struct {
+	text string
+}
+
+func (w *word) UnmarshalText(b []byte) error {
+	w.text = string(b)
+	return nil
+}
+
+func TestTextVarPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		define func(*opts.Group)
+	}{
+		"nil default": {
+			define: func(og *opts.Group) {
+				var addr netip.Addr
+				og.TextVar(&addr, "addr", nil)
+			},
+		},
+		"default of another type": {
+			define: func(og *opts.Group) {
+				var addr netip.Addr
+				og.TextVar(&addr, "addr", slog.LevelInfo)
+			},
+		},
+		"invalid name": {
+			define: func(og *opts.Group) {
+				var addr netip.Addr
+				og.TextVar(&addr, "", netip.Addr{})
+			},
+		},
+		"no MarshalText": {
+			define: func(og *opts.Group) {
+				var w word
+				og.TextVar(&w, "word", netip.Addr{})
+			},
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			og := opts.NewGroup("test-text")
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("expected panic on invalid definition")
+				}
+			}()
+			tc.define(og)
+		})
+	}
+}
@@ -7,7 +7,7 @@ import (
 // Uint defines a uint option with the specified name and default value. The
 // argument u points to a uint variable that will store the value of the
 // option. Uint will panic if name is not valid or repeats an existing option.
-func (g *Group) Uint(u *uint, name string, defValue uint) {
+func (g *Group) Uint(u *uint, name string, defValue uint, mods ...Modifier) {
 	if err := validateName("Uint", name); err != nil {
 		panic(err)
 	}
@@ -18,19 +18,17 @@ func (g *Group) Uint(u *uint, name string, defValue uint) {
 			ptr:     u,
 			convert: toUint,
 		},
-		name:   name,
-		isBool: false,
+		name:     name,
+		typeName: "uint",
+		isBool:   false,
 	}
 
-	if err := g.optAlreadySet(name); err != nil {
-		panic(err)
-	}
-	g.opts[name] = opt
+	g.addOpt("Uint", opt, mods)
 }
 
 // UintZero is like Uint but with a default value of 0.
-func (g *Group) UintZero(u *uint, name string) {
-	g.Uint(u, name, 0)
+func (g *Group) UintZero(u *uint, name string, mods ...Modifier) {
+	g.Uint(u, name, 0, mods...)
 }
 
 func toUint(s string) (uint, error) {
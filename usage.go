@@ -0,0 +1,137 @@
+package opts
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Usage writes a help screen for the options defined in the [Group] to w.
+// Options are sorted by name, and each line shows the option and any aliases,
+// a placeholder for its value (unless it is a boolean), its help text, and
+// its default value (unless the default is the zero value for its type).
+// If the Group has positional arguments, the first line names them, and
+// a second section lists them with their help text.
+//
+//	Usage: caser [options]
+//
+//	Options:
+//	  --convention string  naming convention to enforce (default "camel")
+//	  --dry-run            report problems without fixing them
+//	  --strictness uint    how strictly to check names (default 3)
+func (g *Group) Usage(w io.Writer) {
+	g.usage(w, g.argSynopsis())
+}
+
+// usage writes the help screen for g to w. The synopsis line ends with
+// trailer, which callers use to describe what follows the options.
+func (g *Group) usage(w io.Writer, trailer string) {
+	if len(g.opts) == 0 {
+		fmt.Fprintf(w, "Usage: %s%s\n", g.name, trailer)
+		g.argsUsage(w)
+		return
+	}
+
+	fmt.Fprintf(w, "Usage: %s [options]%s\n\nOptions:\n", g.name, trailer)
+
+	names := g.sortedNames()
+	labels := make([]string, len(names))
+	width := 0
+	for i, name := range names {
+		labels[i] = g.opts[name].label(g.flags(name))
+		width = max(width, len(labels[i]))
+	}
+
+	for i, name := range names {
+		desc := g.opts[name].description()
+		if desc == "" {
+			fmt.Fprintf(w, "  %s\n", labels[i])
+			continue
+		}
+		fmt.Fprintf(w, "  %-*s  %s\n", width, labels[i], desc)
+	}
+
+	g.argsUsage(w)
+}
+
+// flags returns every name of the named option as users type them, the
+// canonical name first.
+func (g *Group) flags(name string) string {
+	names := g.opts[name].names()
+	for i, n := range names {
+		names[i] = g.flag(n)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// flag returns name as users type it on the command line. Single-character
+// names take one dash when clustering is on, and negatable booleans show
+// their "no-" prefix.
+func (g *Group) flag(name string) string {
+	switch {
+	case g.clustering && utf8.RuneCountInString(name) == 1:
+		return "-" + name
+	case g.opts[name].negatable:
+		return "--[no-]" + name
+	default:
+		return "--" + name
+	}
+}
+
+// label returns flag followed by a placeholder for the option's value.
+func (o *opt) label(flag string) string {
+	switch {
+	case o.typeName == "":
+		return flag
+	case o.hasImplicit:
+		return flag + "[=" + o.placeholder() + "]"
+	default:
+		return flag + " " + o.placeholder()
+	}
+}
+
+// placeholder stands for the option's value in help output. The placeholder
+// for an enumerated option lists the choices, and the placeholder for
+// a repeatable option ends with "...". A [Placeholder] modifier replaces the
+// type name or the choices.
+func (o *opt) placeholder() string {
+	p := o.typeName
+	switch {
+	case o.valueName != "":
+		p = o.valueName
+	case len(o.choices) > 0:
+		p = strings.Join(o.choices, "|")
+	}
+
+	if o.repeatable {
+		p += "..."
+	}
+
+	return p
+}
+
+// description returns the option's help text followed by its default value
+// and environment variable, if any.
+func (o *opt) description() string {
+	desc := o.help
+
+	if !o.defZero {
+		def := o.defValue
+		if o.typeName == "string" && !o.repeatable {
+			def = fmt.Sprintf("%q", def)
+		}
+		desc += " (default " + def + ")"
+	}
+
+	if o.envVar != "" {
+		desc += " (env $" + o.envVar + ")"
+	}
+
+	if o.required {
+		desc += " (required)"
+	}
+
+	return strings.TrimSpace(desc)
+}
@@ -0,0 +1,88 @@
+package opts_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+func TestUsage(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		define func(*opts.Group)
+		want   string
+	}{
+		"no options": {
+			define: func(*opts.Group) {},
+			want:   "Usage: test-usage\n",
+		},
+		"zero defaults are omitted": {
+			define: func(og *opts.Group) {
+				var (
+					s string
+					i int
+					b bool
+				)
+				og.StringZero(&s, "name")
+				og.IntZero(&i, "count", opts.Help("how many"))
+				og.Bool(&b, "v", opts.Help("be verbose"))
+			},
+			want: "Usage: test-usage [options]\n\nOptions:\n" +
+				"  --count int    how many\n" +
+				"  --name string\n" +
+				"  --v            be verbose\n",
+		},
+		"non-zero defaults are shown": {
+			define: func(og *opts.Group) {
+				var (
+					d   time.Duration
+					day civil.Date
+					f   float64
+				)
+				og.Duration(&d, "timeout", 5*time.Second, opts.Help("give up after"))
+				og.Date(&day, "since", civil.Date{Year: 2025, Month: 1, Day: 2})
+				og.Float64(&f, "ratio", 0.5)
+			},
+			want: "Usage: test-usage [options]\n\nOptions:\n" +
+				"  --ratio float64     (default 0.5)\n" +
+				"  --since date        (default 2025-01-02)\n" +
+				"  --timeout duration  give up after (default 5s)\n",
+		},
+		"repeatable options": {
+			define: func(og *opts.Group) {
+				var s []string
+				og.StringSlice(&s, "include", []string{"a", "b"})
+			},
+			want: "Usage: test-usage [options]\n\nOptions:\n" +
+				"  --include string...  (default [a b])\n",
+		},
+		"environment variables are shown": {
+			define: func(og *opts.Group) {
+				var s string
+				og.String(&s, "rcfile", "caser.ini", opts.Help("config file"), opts.Env("CASER_RCFILE"))
+			},
+			want: "Usage: test-usage [options]\n\nOptions:\n" +
+				"  --rcfile string  config file (default \"caser.ini\") (env $CASER_RCFILE)\n",
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			og := opts.NewGroup("test-usage")
+			tc.define(og)
+
+			var b strings.Builder
+			og.Usage(&b)
+			if diff := cmp.Diff(tc.want, b.String()); diff != "" {
+				t.Errorf("og.Usage(); (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package opts
+
+import "errors"
+
+// Value is the interface to the value of an option with a custom type. Set
+// parses a string from the command line, the environment, or a configuration
+// file and stores the result, returning an error if the string is not valid.
+// String renders the current value for help output and for [Option].
+//
+// If a Value has an IsBoolFlag method that returns true, the option is
+// a switch, like one defined by [*Group.Bool]: it takes no argument on the
+// command line, and Set is called with "true". If a Value has a Type method,
+// help output uses its result as the placeholder for the option's value.
+// These methods make any [flag.Value] usable as a Value.
+type Value interface {
+	Set(string) error
+	String() string
+}
+
+// customValue adapts a Value to the setter interface.
+type customValue struct {
+	v Value
+}
+
+func (c *customValue) set(s string) error {
+	return c.v.Set(s)
+}
+
+func (c *customValue) String() string {
+	return c.v.String()
+}
+
+// save cannot copy an arbitrary Value, so it restores the value by passing
+// its current rendering back to Set. This is exact for most types, but not
+// for a Value that accumulates, such as one that appends to a list.
+func (c *customValue) save() func() {
+	saved := c.v.String()
+	return func() {
+		if err := c.v.Set(saved); err != nil {
+			return // Keep whatever the failed parse left.
+		}
+	}
+}
+
+func (c *customValue) isZero() bool {
+	return looksZero(c.v.String())
+}
+
+// looksZero reports whether s is a usual rendering of a zero value. Custom
+// types give no way to find their zero value, so this guess decides whether
+// help output shows their default, much as in the flag package.
+func looksZero(s string) bool {
+	switch s {
+	case "", "0", "false":
+		return true
+	default:
+		return false
+	}
+}
+
+// Var defines an option with the specified name whose value is stored in v.
+// The option's default is whatever v holds when Var is called. Errors from
+// v.Set are reported as an [*InvalidValueError]. Var will panic if v is nil,
+// if name is not valid, or if name repeats an existing option.
+func (g *Group) Var(v Value, name string, mods ...Modifier) {
+	if err := validateName("Var", name); err != nil {
+		panic(err)
+	}
+
+	if v == nil {
+		panic(errors.New("opts: Var: nil Value"))
+	}
+
+	typeName := "value"
+	if t, ok := v.(interface{ Type() string }); ok {
+		typeName = t.Type()
+	}
+
+	isBool := isBoolFlag(v)
+	if isBool {
+		typeName = ""
+	}
+
+	opt := &opt{
+		value:    &customValue{v: v},
+		name:     name,
+		typeName: typeName,
+		isBool:   isBool,
+	}
+
+	g.addOpt("Var", opt, mods)
+}
+
+// isBoolFlag reports whether v has an IsBoolFlag method that returns true.
+func isBoolFlag(v Value) bool {
+	b, ok := v.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// VarOf defines an option of any type with the specified name and default
+// value. The argument p points to a variable that will store the value of
+// the option, and convert parses a string into a value of the option's type.
+// Errors from convert are reported as an [*InvalidValueError], and help output
+// renders values with [fmt.Sprint]. E.g.,
+//
+//	opts.VarOf(og, &cfg.endpoint, "endpoint", defaultURL, url.Parse)
+//
+// VarOf will panic if convert is nil, if name is not valid, or if name
+// repeats an existing option. VarOf is a function rather than a method
+// because methods cannot have type parameters.
+func VarOf[T any](g *Group, p *T, name string, defValue T, convert func(string) (T, error), mods ...Modifier) {
+	if err := validateName("VarOf", name); err != nil {
+		panic(err)
+	}
+
+	if convert == nil {
+		panic(errors.New("opts: VarOf: nil convert function"))
+	}
+
+	*p = defValue
+	opt := &opt{
+		value: &value[T]{
+			ptr:     p,
+			convert: convert,
+		},
+		name:     name,
+		typeName: "value",
+		isBool:   false,
+	}
+
+	g.addOpt("VarOf", opt, mods)
+}
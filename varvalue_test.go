@@ -0,0 +1,279 @@
+package opts_test
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/telemachus/opts"
+)
+
+// grade is a custom Value with a Type method.
+type grade int
+
+func (l *grade) Set(s string) error {
+	switch s {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return fmt.Errorf("unknown level %q", s)
+	}
+	return nil
+}
+
+func (l *grade) String() string {
+	switch *l {
+	case 1:
+		return "low"
+	case 2:
+		return "high"
+	default:
+		return ""
+	}
+}
+
+func (l *grade) Type() string {
+	return "level"
+}
+
+// toggle is a custom Value that acts as a switch.
+type toggle struct {
+	on bool
+}
+
+func (t *toggle) Set(s string) error {
+	t.on = s == "true"
+	return nil
+}
+
+func (t *toggle) String() string {
+	return fmt.Sprint(t.on)
+}
+
+func (t *toggle) IsBoolFlag() bool {
+	return true
+}
+
+func TestParseVar(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args       []string
+		wantLevel  grade
+		wantToggle bool
+	}{
+		"default": {
+			args:      []string{},
+			wantLevel: 1,
+		},
+		"value given": {
+			args:      []string{"--level", "high"},
+			wantLevel: 2,
+		},
+		"switch given": {
+			args:       []string{"--toggle"},
+			wantLevel:  1,
+			wantToggle: true,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			lvl := grade(1)
+			var tog toggle
+			og := opts.NewGroup("test-var")
+			og.Var(&lvl, "level")
+			og.Var(&tog, "toggle")
+
+			if err := og.Parse(tc.args); err != nil {
+				t.Fatalf("og.Parse(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if lvl != tc.wantLevel {
+				t.Errorf("og.Parse(%v) sets level to %v; want %v", tc.args, lvl, tc.wantLevel)
+			}
+
+			if tog.on != tc.wantToggle {
+				t.Errorf("og.Parse(%v) sets toggle to %t; want %t", tc.args, tog.on, tc.wantToggle)
+			}
+		})
+	}
+}
+
+func TestParseVarErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args      []string
+		errWanted error
+	}{
+		"invalid value": {
+			args:      []string{"--level", "medium"},
+			errWanted: &opts.InvalidValueError{},
+		},
+		"missing value": {
+			args:      []string{"--level"},
+			errWanted: opts.ErrMissingValue,
+		},
+		"switch with value": {
+			args:      []string{"--toggle=true"},
+			errWanted: opts.ErrBooleanWithValue,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				lvl grade
+				tog toggle
+			)
+			og := opts.NewGroup("test-var")
+			og.Var(&lvl, "level")
+			og.Var(&tog, "toggle")
+			err := og.Parse(tc.args)
+
+			var ive *opts.InvalidValueError
+			if errors.As(tc.errWanted, &ive) {
+				if !errors.As(err, &ive) || ive.Option != "level" {
+					t.Errorf("og.Parse(%v) returns err == %v; want InvalidValueError for --level", tc.args, err)
+				}
+				return
+			}
+
+			if !errors.Is(err, tc.errWanted) {
+				t.Errorf("og.Parse(%v) returns err == %v; want %v", tc.args, err, tc.errWanted)
+			}
+		})
+	}
+}
+
+func TestParseVarOf(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		"default": {
+			args: []string{},
+			want: "https://example.com",
+		},
+		"value given": {
+			args: []string{"--endpoint", "https://example.org/api"},
+			want: "https://example.org/api",
+		},
+		"invalid value": {
+			args:    []string{"--endpoint", "%zz"},
+			wantErr: true,
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			def, _ := url.Parse("https://example.com")
+			var endpoint *url.URL
+			og := opts.NewGroup("test-var")
+			opts.VarOf(og, &endpoint, "endpoint", def, url.Parse)
+			err := og.Parse(tc.args)
+
+			if tc.wantErr {
+				var ive *opts.InvalidValueError
+				if !errors.As(err, &ive) {
+					t.Errorf("og.Parse(%v) returns err == %v; want InvalidValueError", tc.args, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("og.Parse(%v) returns err == %v; want nil", tc.args, err)
+			}
+
+			if got := endpoint.String(); got != tc.want {
+				t.Errorf("og.Parse(%v) sets endpoint to %q; want %q", tc.args, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestVarUsage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lvl  grade
+		tog  toggle
+		size int
+	)
+	og := opts.NewGroup("test-var")
+	og.Var(&lvl, "level")
+	og.Var(&tog, "toggle")
+	opts.VarOf(og, &size, "size", 10, func(s string) (int, error) {
+		return len(s), nil
+	}, opts.Placeholder("text"))
+
+	var b strings.Builder
+	og.Usage(&b)
+
+	want := "Usage: test-var [options]\n\nOptions:\n" +
+		"  --level level\n" +
+		"  --size text    (default 10)\n" +
+		"  --toggle\n"
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("og.Usage(); (-want +got):\n%s", diff)
+	}
+}
+
+func TestVarPanics(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		define func(*opts.Group)
+	}{
+		"nil Value": {
+			define: func(og *opts.Group) { og.Var(nil, "level") },
+		},
+		"nil convert": {
+			define: func(og *opts.Group) {
+				var n int
+				opts.VarOf[int](og, &n, "n", 0, nil)
+			},
+		},
+		"invalid name": {
+			define: func(og *opts.Group) {
+				var lvl grade
+				og.Var(&lvl, "-level")
+			},
+		},
+		"placeholder for a switch": {
+			define: func(og *opts.Group) {
+				var tog toggle
+				og.Var(&tog, "toggle", opts.Placeholder("x"))
+			},
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			t.Parallel()
+
+			og := opts.NewGroup("test-var")
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("expected panic on invalid definition")
+				}
+			}()
+			tc.define(og)
+		})
+	}
+}